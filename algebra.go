@@ -0,0 +1,54 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "io"
+
+/*
+An algebra over coefficient slices laid out like MultiComp. Modulus (the
+multicomplex numbers) and Jet (truncated power series) implement it, and
+the protocols built on Group work with either.
+
+Inverse returns nil if its argument is not a unit.
+
+Note that a Group is only as strong as discrete logarithms in the algebra.
+Jet in particular is NOT suitable for cryptographic use: its units split as
+F_P^* x (1 + x*F_P[x]), and Jet.Logarithm solves discrete logarithms in the
+second factor in polynomial time. Use Jet as a Group backend only for
+testing.
+*/
+type Algebra interface{
+	Add(a,b MultiComp) MultiComp
+	Sub(a,b MultiComp) MultiComp
+	Neg(a MultiComp) MultiComp
+	Multiply(a,b MultiComp) MultiComp
+	Inverse(a MultiComp) MultiComp
+	Exp(g MultiComp, exp []byte) MultiComp
+	Deterministic(source io.Reader, size int) (MultiComp,error)
+	Encode(a MultiComp) []byte
+	Decode(b []byte) (MultiComp,error)
+}
+
+var _ Algebra = Modulus{}
+var _ Algebra = Jet{}
@@ -0,0 +1,185 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math/big"
+import "crypto/rand"
+import "io"
+import "fmt"
+import "errors"
+
+/*
+A sealed-bid first-price auction run by threshold authorities.
+
+Each bidder encrypts its bid bit by bit under the joint key and proves every
+bit to be 0 or 1. The authorities then evaluate the maximum MSB-first: a
+bidder stays "active" while its bid agrees with the maximum so far. For each
+bit, c_i = active_i AND bit_i is computed by mix-and-match; the homomorphic
+sum of the c_i is tested for zero, which yields the bit of the maximum, and
+active_i becomes c_i whenever that bit is 1. Only the bits of the winning
+price and the set of winners are decrypted; losing bids are never revealed.
+
+Zero tests, decryptions and blindings carry Chaum-Pedersen proofs. The
+rerandomised shuffles of the mix-and-match tables carry no shuffle proofs,
+so the authorities are trusted to mix honestly (but not to learn bids: fewer
+than T of them learn nothing).
+*/
+type Auction struct{
+	Key *ThresholdKey
+	Bits int
+}
+
+// An encrypted bid, most significant bit first.
+type Bid struct{
+	Bits []Ciphertext
+	Proofs []*BitProof
+}
+
+type Outcome struct{
+	Price uint64
+	Winners []int // indices into the bids
+}
+
+func NewAuction(key *ThresholdKey, bits int) (*Auction,error) {
+	if bits<1 || bits>64 { return nil,errors.New("Bid width must be between 1 and 64 bits") }
+	return &Auction{key,bits},nil
+}
+
+func (a *Auction) SealBid(rand io.Reader, value uint64) (*Bid,error) {
+	if a.Bits<64 && value>>uint(a.Bits)!=0 { return nil,errors.New("Bid out of range") }
+	sg := a.Key.Subgroup
+	b := new(Bid)
+	for k := a.Bits-1; k>=0; k-- {
+		bit := int(value>>uint(k))&1
+		c,r,e := sg.Encrypt(rand,a.Key.Y,big.NewInt(int64(bit)))
+		if e!=nil { return nil,e }
+		p,e := sg.ProveBit(rand,a.Key.Y,c,bit,r)
+		if e!=nil { return nil,e }
+		b.Bits = append(b.Bits,c)
+		b.Proofs = append(b.Proofs,p)
+	}
+	return b,nil
+}
+
+func (a *Auction) VerifyBid(b *Bid) error {
+	if b==nil || len(b.Bits)!=a.Bits || len(b.Proofs)!=a.Bits { return errors.New("Malformed bid") }
+	for k := range b.Bits {
+		if !a.Key.VerifyBit(a.Key.Y,b.Bits[k],b.Proofs[k]) { return fmt.Errorf("Bad proof for bit %d",k) }
+	}
+	return nil
+}
+
+// One row of a mix-and-match table: encryptions of x, y and x AND y.
+type gateRow [3]Ciphertext
+
+func (a *Auction) andTable(rand io.Reader, auths []*Authority) ([]gateRow,error) {
+	k := a.Key
+	var tab []gateRow
+	for x := int64(0); x<2; x++ {
+		for y := int64(0); y<2; y++ {
+			tab = append(tab,gateRow{k.Trivial(big.NewInt(x)),k.Trivial(big.NewInt(y)),k.Trivial(big.NewInt(x&y))})
+		}
+	}
+	for range auths {
+		for i := range tab {
+			for j := range tab[i] {
+				c,e := k.Rerandomize(rand,k.Y,tab[i][j])
+				if e!=nil { return nil,e }
+				tab[i][j] = c
+			}
+		}
+		for i := len(tab)-1; i>0; i-- {
+			j,e := randIndex(rand,i+1)
+			if e!=nil { return nil,e }
+			tab[i],tab[j] = tab[j],tab[i]
+		}
+	}
+	return tab,nil
+}
+
+func randIndex(r io.Reader, n int) (int,error) {
+	j,e := rand.Int(r,big.NewInt(int64(n)))
+	if e!=nil { return 0,e }
+	return int(j.Int64()),nil
+}
+
+/*
+Evaluates x AND y by mix-and-match: the row whose inputs match (x, y) is
+found by testing (x-x') + 2*(y-y') for zero, which for bits is zero only if
+both differences are.
+*/
+func (a *Auction) and(rand io.Reader, x,y Ciphertext, auths []*Authority) (Ciphertext,error) {
+	k := a.Key
+	tab,e := a.andTable(rand,auths)
+	if e!=nil { return Ciphertext{},e }
+	two := big.NewInt(2)
+	for _,row := range tab {
+		d := k.Add(k.Sub(x,row[0]),k.Scale(k.Sub(y,row[1]),two))
+		z,e := k.IsZero(rand,d,auths)
+		if e!=nil { return Ciphertext{},e }
+		if z { return row[2],nil }
+	}
+	return Ciphertext{},errors.New("No row of the gate table matched")
+}
+
+/*
+Determines the highest bid and its bidders. Invalid bids are rejected with an
+error. The first T authorities jointly decrypt; all of them mix and blind.
+*/
+func (a *Auction) Resolve(rand io.Reader, bids []*Bid, auths []*Authority) (*Outcome,error) {
+	if len(bids)==0 { return nil,errors.New("No bids") }
+	if len(auths)<a.Key.T { return nil,errors.New("Not enough authorities") }
+	for i,b := range bids {
+		if e := a.VerifyBid(b); e!=nil { return nil,fmt.Errorf("Bid %d: %v",i,e) }
+	}
+	k := a.Key
+	active := make([]Ciphertext,len(bids))
+	for i := range active { active[i] = k.Trivial(big.NewInt(1)) }
+	out := new(Outcome)
+	for bit := 0; bit<a.Bits; bit++ {
+		c := make([]Ciphertext,len(bids))
+		sum := k.Trivial(new(big.Int))
+		for i,b := range bids {
+			var e error
+			if c[i],e = a.and(rand,active[i],b.Bits[bit],auths); e!=nil { return nil,e }
+			sum = k.Add(sum,c[i])
+		}
+		z,e := k.IsZero(rand,sum,auths)
+		if e!=nil { return nil,e }
+		out.Price <<= 1
+		if !z {
+			out.Price |= 1
+			active = c
+		}
+	}
+	g := k.G
+	for i := range active {
+		m,e := k.Decrypt(rand,active[i],auths)
+		if e!=nil { return nil,e }
+		if m.Equal(g) { out.Winners = append(out.Winners,i) } else if !isOne(m) {
+			return nil,errors.New("Auction state is corrupt")
+		}
+	}
+	return out,nil
+}
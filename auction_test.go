@@ -0,0 +1,79 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "reflect"
+
+func TestAuction(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	key,auths,e := sg.GenerateThreshold(rng,3,2)
+	if e!=nil { t.Fatal(e) }
+	a,e := NewAuction(key,3)
+	if e!=nil { t.Fatal(e) }
+	for i,c := range []struct{
+		bids []uint64
+		price uint64
+		winners []int
+	}{
+		{[]uint64{5,3,5,1},5,[]int{0,2}},
+		{[]uint64{6},6,[]int{0}},
+		{[]uint64{0,0},0,[]int{0,1}},
+		{[]uint64{1,7,6},7,[]int{1}},
+	} {
+		if testing.Short() && i>0 { continue }
+		var bids []*Bid
+		for _,v := range c.bids {
+			b,e := a.SealBid(rng,v)
+			if e!=nil { t.Fatal(e) }
+			if e := a.VerifyBid(b); e!=nil { t.Fatal(e) }
+			bids = append(bids,b)
+		}
+		out,e := a.Resolve(rng,bids,auths)
+		if e!=nil { t.Fatal(e) }
+		if out.Price!=c.price || !reflect.DeepEqual(out.Winners,c.winners) {
+			t.Errorf("bids %v: price %d, winners %v; want %d, %v",c.bids,out.Price,out.Winners,c.price,c.winners)
+		}
+	}
+}
+
+func TestAuctionRejects(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	key,auths,e := sg.GenerateThreshold(rng,3,2)
+	if e!=nil { t.Fatal(e) }
+	for _,bits := range []int{0,65} {
+		if _,e := NewAuction(key,bits); e==nil { t.Errorf("%d-bit auction accepted",bits) }
+	}
+	a,_ := NewAuction(key,3)
+	if _,e := a.SealBid(rng,8); e==nil { t.Error("bid out of range accepted") }
+	good,_ := a.SealBid(rng,5)
+	b,_ := a.SealBid(rng,5)
+	b.Bits[0],b.Bits[1] = b.Bits[1],b.Bits[0]
+	if a.VerifyBid(b)==nil { t.Error("bid with swapped bits accepted") }
+	short := &Bid{good.Bits[:2],good.Proofs[:2]}
+	if a.VerifyBid(short)==nil { t.Error("short bid accepted") }
+	if _,e := a.Resolve(rng,[]*Bid{good,b},auths); e==nil { t.Error("Resolve accepted an invalid bid") }
+	if _,e := a.Resolve(rng,[]*Bid{good},auths[:1]); e==nil { t.Error("Resolve ran below the threshold") }
+	if _,e := a.Resolve(rng,nil,auths); e==nil { t.Error("Resolve ran without bids") }
+}
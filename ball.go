@@ -0,0 +1,243 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math"
+import "math/big"
+import "bytes"
+import "errors"
+
+var ErrContainsZero = errors.New("Ball contains zero")
+
+/*
+A ball [Mid-Rad, Mid+Rad] of real numbers. Rad is an upper bound; every
+operation on balls returns a ball that contains all possible results for
+all values in the operand balls.
+*/
+type Ball struct{
+	Mid,Rad *big.Float
+}
+
+// A MultiFloat with Ball coefficients, laid out like MultiComp.
+type MultiBall []Ball
+
+func (m MultiBall) String() string {
+	sb := bytes.NewBuffer([]byte{'['})
+	for i,b := range m {
+		if i>0 { sb.WriteByte(',') }
+		sb.WriteString(b.Mid.Text('g',10))
+		sb.WriteString("±")
+		sb.WriteString(b.Rad.Text('g',3))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+/*
+Ball arithmetic with midpoints of Prec bits. Midpoints are rounded to
+nearest, and each rounding error is added to the radius; radii are computed
+with 64 bits, rounding upwards.
+*/
+type BallField struct{
+	Prec uint
+}
+
+const radPrec = 64
+
+func newRad() *big.Float { return new(big.Float).SetPrec(radPrec).SetMode(big.ToPositiveInf) }
+func (f BallField) newMid() *big.Float { return new(big.Float).SetPrec(f.Prec) }
+
+// An upper bound of the rounding error of m, if m was rounded.
+func (f BallField) roundErr(m *big.Float) *big.Float {
+	r := newRad()
+	if m.Acc()==big.Exact || m.Sign()==0 { return r }
+	return r.SetMantExp(big.NewFloat(1),m.MantExp(nil)-int(f.Prec))
+}
+
+func (f BallField) ball(m,r *big.Float) Ball {
+	return Ball{Mid:m,Rad:r.Add(r,f.roundErr(m))}
+}
+
+// Encloses x.
+func (f BallField) FromFloat(x MultiFloat) MultiBall {
+	b := make(MultiBall,len(x))
+	for i,c := range x {
+		b[i] = f.ball(f.newMid().SetFloat64(c),newRad())
+	}
+	return b
+}
+
+func (f BallField) Add(a,b MultiBall) MultiBall {
+	c := make(MultiBall,len(a))
+	for i := range c {
+		c[i] = f.ball(f.newMid().Add(a[i].Mid,b[i].Mid),newRad().Add(a[i].Rad,b[i].Rad))
+	}
+	return c
+}
+func (f BallField) Sub(a,b MultiBall) MultiBall {
+	c := make(MultiBall,len(a))
+	for i := range c {
+		c[i] = f.ball(f.newMid().Sub(a[i].Mid,b[i].Mid),newRad().Add(a[i].Rad,b[i].Rad))
+	}
+	return c
+}
+func (f BallField) Neg(a MultiBall) MultiBall {
+	c := make(MultiBall,len(a))
+	for i := range c {
+		c[i] = f.ball(f.newMid().Neg(a[i].Mid),newRad().Set(a[i].Rad))
+	}
+	return c
+}
+
+// [m1±r1]*[m2±r2] is in [m1*m2 ± |m1|r2 + |m2|r1 + r1r2].
+func (f BallField) mul(x,y Ball) Ball {
+	r := newRad().Mul(new(big.Float).Abs(x.Mid),y.Rad)
+	r.Add(r,newRad().Mul(new(big.Float).Abs(y.Mid),x.Rad))
+	r.Add(r,newRad().Mul(x.Rad,y.Rad))
+	return f.ball(f.newMid().Mul(x.Mid,y.Mid),r)
+}
+
+// 1/[m±r] is in [1/m ± r/(|m|(|m|-r))], if |m| > r.
+func (f BallField) inv(x Ball) (Ball,error) {
+	am := new(big.Float).Abs(x.Mid)
+	if am.Cmp(x.Rad)<=0 { return Ball{},ErrContainsZero }
+	down := func() *big.Float { return new(big.Float).SetPrec(radPrec).SetMode(big.ToNegativeInf) }
+	d := down().Sub(am,x.Rad)
+	d = down().Mul(d,am)
+	return f.ball(f.newMid().Quo(big.NewFloat(1),x.Mid),newRad().Quo(x.Rad,d)),nil
+}
+
+// Same recursion as Modulus.Multiply.
+func (f BallField) Multiply(a,b MultiBall) MultiBall {
+	L := len(a)/2
+	if L==0 { return MultiBall{f.mul(a[0],b[0])} }
+	ar,ai := a[:L],a[L:]
+	br,bi := b[:L],b[L:]
+	cr := f.Sub(f.Multiply(ar,br),f.Multiply(ai,bi))
+	ci := f.Add(f.Multiply(ar,bi),f.Multiply(ai,br))
+	return append(cr,ci...)
+}
+
+func (f BallField) Counterpart(a MultiBall) MultiBall {
+	L := len(a)/2
+	if L==0 { return a }
+	return append(append(MultiBall(nil),a[:L]...),f.Neg(a[L:])...)
+}
+
+func exactZero(a MultiBall) bool {
+	for _,c := range a {
+		if c.Mid.Sign()!=0 || c.Rad.Sign()!=0 { return false }
+	}
+	return true
+}
+
+/*
+Encloses the inverse of every number in a, computed like Modulus.Inverse.
+Since imaginary(x * counterpart(x)) = 0 holds exactly for every x in a, the
+enclosure of that imaginary part is replaced by an exact zero. Fails with
+ErrContainsZero if a ball on the way contains zero (a may then contain a
+zero divisor).
+*/
+func (f BallField) Inverse(a MultiBall) (MultiBall,error) {
+	L := len(a)/2
+	if L==0 {
+		r,e := f.inv(a[0])
+		if e!=nil { return nil,e }
+		return MultiBall{r},nil
+	}
+	ar,ai := a[:L],a[L:]
+	if exactZero(ai) {
+		r,e := f.Inverse(ar)
+		if e!=nil { return nil,e }
+		return append(r,ai...),nil
+	}
+	cp := f.Counterpart(a)
+	prod := f.Multiply(a,cp)
+	r,e := f.Inverse(prod[:L])
+	if e!=nil { return nil,e }
+	zero := make(MultiBall,L)
+	for i := range zero { zero[i] = Ball{Mid:f.newMid(),Rad:newRad()} }
+	return f.Multiply(append(r,zero...),cp),nil
+}
+
+// The midpoints, rounded to float64.
+func (m MultiBall) Mid() MultiFloat {
+	x := make(MultiFloat,len(m))
+	for i,c := range m { x[i],_ = c.Mid.Float64() }
+	return x
+}
+
+func up(x *big.Float) float64 {
+	v,acc := x.Float64()
+	if acc==big.Below { v = math.Nextafter(v,math.Inf(1)) }
+	return v
+}
+
+// The largest radius, rounded upwards.
+func (m MultiBall) Radius() float64 {
+	r := 0.0
+	for _,c := range m { r = math.Max(r,up(c.Rad)) }
+	return r
+}
+
+/*
+An upper bound of the error of x against every number in m, that is,
+of max |x[i] - y[i]| for all y in m. This certifies float computations:
+the exact result lies within Error(x) of x. The bound is +Inf if it
+exceeds the float64 range or x is not finite.
+*/
+func (m MultiBall) Error(x MultiFloat) float64 {
+	e := 0.0
+	for i,c := range m {
+		if math.IsInf(x[i],0) || math.IsNaN(x[i]) { return math.Inf(1) }
+		d := c.dist(x[i])
+		v,_ := d.Float64()
+		if math.IsInf(v,1) { return v }
+		if new(big.Rat).SetFloat64(v).Cmp(d)<0 { v = math.Nextafter(v,math.Inf(1)) }
+		e = math.Max(e,v)
+	}
+	return e
+}
+
+// |x-Mid|+Rad, exactly.
+func (b Ball) dist(x float64) *big.Rat {
+	d := new(big.Rat).SetFloat64(x)
+	mid,_ := b.Mid.Rat(nil)
+	rad,_ := b.Rad.Rat(nil)
+	d.Sub(d,mid)
+	return d.Add(d.Abs(d),rad)
+}
+
+// Reports whether every coefficient of x lies within its ball.
+func (m MultiBall) Contains(x MultiFloat) bool {
+	for i,c := range m {
+		if math.IsInf(x[i],0) || math.IsNaN(x[i]) { return false }
+		d := new(big.Rat).SetFloat64(x[i])
+		mid,_ := c.Mid.Rat(nil)
+		rad,_ := c.Rad.Rat(nil)
+		d.Sub(d,mid)
+		if d.Abs(d).Cmp(rad)>0 { return false }
+	}
+	return true
+}
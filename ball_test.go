@@ -0,0 +1,148 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math"
+import "math/big"
+import "math/rand"
+
+// Exact multicomplex arithmetic over the rationals, as a reference.
+type ratComp []*big.Rat
+
+func toRat(x MultiFloat) ratComp {
+	r := make(ratComp,len(x))
+	for i,c := range x { r[i] = new(big.Rat).SetFloat64(c) }
+	return r
+}
+func (a ratComp) add(b ratComp, sign int) ratComp {
+	c := make(ratComp,len(a))
+	for i := range c {
+		c[i] = new(big.Rat).Set(b[i])
+		if sign<0 { c[i].Neg(c[i]) }
+		c[i].Add(c[i],a[i])
+	}
+	return c
+}
+func (a ratComp) mul(b ratComp) ratComp {
+	L := len(a)/2
+	if L==0 { return ratComp{new(big.Rat).Mul(a[0],b[0])} }
+	cr := a[:L].mul(b[:L]).add(a[L:].mul(b[L:]),-1)
+	ci := a[:L].mul(b[L:]).add(a[L:].mul(b[:L]),1)
+	return append(cr,ci...)
+}
+func (a ratComp) inv() ratComp {
+	L := len(a)/2
+	if L==0 { return ratComp{new(big.Rat).Inv(a[0])} }
+	zero := make(ratComp,L)
+	for i := range zero { zero[i] = new(big.Rat) }
+	cp := append(append(ratComp(nil),a[:L]...),zero.add(a[L:],-1)...)
+	prod := a.mul(cp)
+	return append(prod[:L].inv(),zero...).mul(cp)
+}
+
+func ballContains(t *testing.T, what string, m MultiBall, x ratComp) {
+	t.Helper()
+	for i,c := range m {
+		mid,_ := c.Mid.Rat(nil)
+		rad,_ := c.Rad.Rat(nil)
+		d := new(big.Rat).Sub(x[i],mid)
+		if d.Abs(d).Cmp(rad)>0 { t.Fatalf("%s: coefficient %d, %v not within %v",what,i,x[i].FloatString(20),m) }
+	}
+}
+
+func randFloat(rng *rand.Rand, n int) MultiFloat {
+	x := make(MultiFloat,n)
+	for i := range x { x[i] = (rng.Float64()-0.5)*math.Pow(2,float64(rng.Intn(40)-20)) }
+	return x
+}
+
+func TestBallEnclosure(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _,prec := range []uint{24,53,200} {
+		f := BallField{prec}
+		for _,n := range []int{1,2,4,8} {
+			for it := 0; it<20; it++ {
+				x,y := randFloat(rng,n),randFloat(rng,n)
+				bx,by := f.FromFloat(x),f.FromFloat(y)
+				rx,ry := toRat(x),toRat(y)
+				ballContains(t,"FromFloat",bx,rx)
+				ballContains(t,"Add",f.Add(bx,by),rx.add(ry,1))
+				ballContains(t,"Sub",f.Sub(bx,by),rx.add(ry,-1))
+				prod := f.Multiply(bx,by)
+				ballContains(t,"Multiply",prod,rx.mul(ry))
+				// Operations on wide balls must still enclose.
+				ballContains(t,"Multiply twice",f.Multiply(prod,bx),rx.mul(ry).mul(rx))
+				inv,e := f.Inverse(bx)
+				if e!=nil { t.Fatalf("prec %d: Inverse(%v): %v",prec,x,e) }
+				ballContains(t,"Inverse",inv,rx.inv())
+				if err := inv.Error(inv.Mid()); math.IsNaN(err) || err<inv.Radius() {
+					t.Fatalf("Error %g below the radius %g",err,inv.Radius())
+				}
+			}
+		}
+	}
+}
+
+func TestBallErrors(t *testing.T) {
+	f := BallField{53}
+	a := MultiBall{{Mid:big.NewFloat(1),Rad:big.NewFloat(2)}}
+	if _,e := f.Inverse(a); e!=ErrContainsZero { t.Errorf("got %v, want ErrContainsZero",e) }
+	zd := f.FromFloat(MultiFloat{1,0,0,1}) // 1+i1*i2
+	if _,e := f.Inverse(zd); e!=ErrContainsZero { t.Errorf("zero divisor: got %v, want ErrContainsZero",e) }
+	huge := MultiBall{{Mid:new(big.Float).SetMantExp(big.NewFloat(1),5000),Rad:big.NewFloat(0)}}
+	if e := huge.Error(MultiFloat{0}); !math.IsInf(e,1) { t.Errorf("Error = %g, want +Inf",e) }
+	if e := f.FromFloat(MultiFloat{1}).Error(MultiFloat{math.NaN()}); !math.IsInf(e,1) { t.Errorf("Error(NaN) = %g",e) }
+	if f.FromFloat(MultiFloat{1}).Contains(MultiFloat{math.Inf(1)}) { t.Error("ball contains +Inf") }
+	b := f.FromFloat(MultiFloat{0.1,3})
+	if !b.Contains(MultiFloat{0.1,3}) || b.Contains(MultiFloat{0.1,3.0000001}) { t.Error("Contains") }
+}
+
+// Balls from a more precise BallField must still be enclosed after rounding.
+func TestBallRounding(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	hi,lo := BallField{200},BallField{24}
+	three := big.NewFloat(3)
+	for _,n := range []int{1,2,4} {
+		for it := 0; it<10; it++ {
+			x := randFloat(rng,n)
+			b := make(MultiBall,n)
+			for i := range b {
+				b[i] = hi.ball(hi.newMid().Quo(big.NewFloat(x[i]),three),newRad())
+			}
+			r := make(ratComp,n)
+			for i,c := range b { r[i],_ = c.Mid.Rat(nil) }
+			zero := make(ratComp,n)
+			for i := range zero { zero[i] = new(big.Rat) }
+			ballContains(t,"Neg",lo.Neg(b),zero.add(r,-1))
+			if n>1 {
+				cp := append(append(ratComp(nil),r[:n/2]...),zero[:n/2].add(r[n/2:],-1)...)
+				ballContains(t,"Counterpart",lo.Counterpart(b),cp)
+			}
+			inv,e := lo.Inverse(b)
+			if e!=nil { t.Fatal(e) }
+			ballContains(t,"Inverse",inv,r.inv())
+		}
+	}
+}
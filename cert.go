@@ -0,0 +1,209 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package hypercomplex
+
+import "math/big"
+import "bytes"
+import "fmt"
+import "strconv"
+import "strings"
+import "errors"
+
+// Primes below 2^smallCertBits are certified by trial division alone.
+const smallCertBits = 32
+
+/*
+A Pocklington primality certificate for N.
+
+Pocklington's theorem: let N-1 = F*R with F > sqrt(N) and F fully factored.
+If for every prime q dividing F there is an 'a' with a^(N-1) = 1 (mod N) and
+gcd(a^((N-1)/q) - 1, N) = 1, then N is prime. Each step gives one such q,
+its multiplicity in F and its witness 'a', together with a certificate for q.
+When F = N-1 this is a Pratt certificate.
+
+Numbers below 2^32 carry no steps; they are checked by trial division.
+
+Certificates are checked with Verify, which uses no probabilistic test, so a
+certificate can be shipped alongside a parameter and checked offline.
+Elliptic curve (ECPP) certificates are not supported; N-1 must be
+sufficiently smooth for ProvePrime to succeed.
+*/
+type Certificate struct{
+	N *big.Int
+	Steps []CertStep
+}
+
+type CertStep struct{
+	Q *big.Int
+	E int
+	A *big.Int
+	Cert *Certificate // nil if Q < 2^32
+}
+
+func isSmallPrime(n *big.Int) bool {
+	if n.Cmp(big.NewInt(2))<0 { return false }
+	r := new(big.Int)
+	for _,p := range smallPrimes(1<<(smallCertBits/2)) {
+		bp := new(big.Int).SetUint64(p)
+		if new(big.Int).Mul(bp,bp).Cmp(n)>0 { break }
+		if r.Mod(n,bp).Sign()==0 { return false }
+	}
+	return true
+}
+
+// Checks the certificate. A nil error proves N prime.
+func (c *Certificate) Verify() error {
+	if c.N==nil { return errors.New("Empty certificate") }
+	if c.N.BitLen()<=smallCertBits {
+		if !isSmallPrime(c.N) { return fmt.Errorf("%v is not prime",c.N) }
+		return nil
+	}
+	one := big.NewInt(1)
+	nm1 := new(big.Int).Sub(c.N,one)
+	F := big.NewInt(1)
+	e,g,r := new(big.Int),new(big.Int),new(big.Int)
+	for _,s := range c.Steps {
+		if s.Q==nil || s.A==nil || s.E<1 { return errors.New("Malformed certificate step") }
+		if s.Q.BitLen()<=smallCertBits {
+			if !isSmallPrime(s.Q) { return fmt.Errorf("%v is not prime",s.Q) }
+		} else {
+			if s.Cert==nil || s.Cert.N.Cmp(s.Q)!=0 { return fmt.Errorf("No certificate for %v",s.Q) }
+			if err := s.Cert.Verify(); err!=nil { return err }
+		}
+		F.Mul(F,new(big.Int).Exp(s.Q,big.NewInt(int64(s.E)),nil))
+		if e.Exp(s.A,nm1,c.N).Cmp(one)!=0 { return fmt.Errorf("Witness %v fails for %v",s.A,c.N) }
+		e.Exp(s.A,new(big.Int).Quo(nm1,s.Q),c.N)
+		if g.GCD(nil,nil,e.Sub(e,one),c.N).Cmp(one)!=0 { return fmt.Errorf("Witness %v fails for %v",s.A,c.N) }
+	}
+	if r.Mod(nm1,F).Sign()!=0 { return errors.New("Factors do not divide N-1") }
+	if r.Mul(F,F).Cmp(c.N)<=0 { return errors.New("Factored part of N-1 is too small") }
+	return nil
+}
+
+/*
+Generates a certificate for n. Known factors of n-1 (not necessarily prime)
+may be supplied to spare the factoring; they are also passed on to the
+certificates of the prime factors of n-1 where they apply.
+*/
+func ProvePrime(n *big.Int, known ...*big.Int) (*Certificate,error) {
+	c := &Certificate{N:new(big.Int).Set(n)}
+	if n.BitLen()<=smallCertBits {
+		if !isSmallPrime(n) { return nil,fmt.Errorf("%v is not prime",n) }
+		return c,nil
+	}
+	if !n.ProbablyPrime(20) { return nil,fmt.Errorf("%v is not prime",n) }
+	one := big.NewInt(1)
+	nm1 := new(big.Int).Sub(n,one)
+	var hints []*big.Int
+	r := new(big.Int)
+	for _,k := range known {
+		if k.Sign()>0 && r.Mod(nm1,k).Sign()==0 { hints = append(hints,k) }
+	}
+	f,_ := factorWith(nm1,hints)
+	F := f.Product()
+	if r.Mul(F,F).Cmp(n)<=0 { return nil,fmt.Errorf("Could not factor enough of %v-1",n) }
+	e,g := new(big.Int),new(big.Int)
+	for _,p := range f {
+		s := CertStep{Q:p.P,E:p.E}
+		t := new(big.Int).Quo(nm1,p.P)
+		for a := int64(2); s.A==nil; a++ {
+			A := big.NewInt(a)
+			if e.Exp(A,nm1,n).Cmp(one)!=0 { return nil,fmt.Errorf("%v is not prime",n) }
+			e.Exp(A,t,n)
+			if g.GCD(nil,nil,e.Sub(e,one),n).Cmp(one)==0 { s.A = A }
+		}
+		if p.P.BitLen()>smallCertBits {
+			sub,err := ProvePrime(p.P,known...)
+			if err!=nil { return nil,err }
+			s.Cert = sub
+		}
+		c.Steps = append(c.Steps,s)
+	}
+	return c,nil
+}
+
+/*
+Text form: one line per certified number, dependencies first, the
+certified number last. A line holds N followed by its steps as Q^E:A, all in
+hexadecimal:
+
+	N Q^E:A Q^E:A ...
+*/
+func (c *Certificate) MarshalText() ([]byte,error) {
+	sb := new(bytes.Buffer)
+	seen := make(map[string]bool)
+	var walk func(c *Certificate)
+	walk = func(c *Certificate) {
+		for _,s := range c.Steps {
+			if s.Cert!=nil { walk(s.Cert) }
+		}
+		k := c.N.Text(16)
+		if seen[k] { return }
+		seen[k] = true
+		sb.WriteString(k)
+		for _,s := range c.Steps {
+			fmt.Fprintf(sb," %s^%d:%s",s.Q.Text(16),s.E,s.A.Text(16))
+		}
+		sb.WriteByte('\n')
+	}
+	walk(c)
+	return sb.Bytes(),nil
+}
+
+func (c *Certificate) UnmarshalText(b []byte) error {
+	known := make(map[string]*Certificate)
+	var last *Certificate
+	hex := func(s string) (*big.Int,error) {
+		n,ok := new(big.Int).SetString(s,16)
+		if !ok || n.Sign()<=0 { return nil,fmt.Errorf("Bad number %q",s) }
+		return n,nil
+	}
+	for _,line := range strings.Split(string(b),"\n") {
+		fields := strings.Fields(line)
+		if len(fields)==0 { continue }
+		n,err := hex(fields[0])
+		if err!=nil { return err }
+		cur := &Certificate{N:n}
+		for _,f := range fields[1:] {
+			i := strings.IndexByte(f,'^')
+			j := strings.IndexByte(f,':')
+			if i<0 || j<i { return fmt.Errorf("Bad step %q",f) }
+			var s CertStep
+			if s.Q,err = hex(f[:i]); err!=nil { return err }
+			if s.E,err = strconv.Atoi(f[i+1:j]); err!=nil { return err }
+			if s.A,err = hex(f[j+1:]); err!=nil { return err }
+			if s.Q.BitLen()>smallCertBits {
+				s.Cert = known[s.Q.Text(16)]
+				if s.Cert==nil { return fmt.Errorf("No certificate for %v",s.Q) }
+			}
+			cur.Steps = append(cur.Steps,s)
+		}
+		known[n.Text(16)] = cur
+		last = cur
+	}
+	if last==nil { return errors.New("Empty certificate") }
+	*c = *last
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+
+func TestParamsVerify(t *testing.T) {
+	if len(ParamNames())==0 { t.Fatal("no parameter sets") }
+	for _,n := range ParamNames() {
+		ps,e := Params(n)
+		if e!=nil { t.Fatal(e) }
+		if e := ps.Verify(); e!=nil { t.Errorf("%s: %v",n,e) }
+		bad := *ps
+		bad.Order = new(big.Int).Add(ps.Order,big.NewInt(2))
+		if bad.Verify()==nil { t.Errorf("%s: order not matching its certificate accepted",n) }
+		bad = *ps
+		bad.Dim = 3
+		if bad.Verify()==nil { t.Errorf("%s: dimension 3 accepted",n) }
+	}
+	if _,e := Params("no-such-set"); e==nil { t.Error("unknown parameter set found") }
+}
+
+func TestProvePrime(t *testing.T) {
+	for _,start := range []int64{1<<20,1<<40,1<<60} {
+		p := safePrime(start)
+		c,e := ProvePrime(p)
+		if e!=nil { t.Fatal(e) }
+		if e := c.Verify(); e!=nil { t.Fatalf("certificate of %v: %v",p,e) }
+		txt,e := c.MarshalText()
+		if e!=nil { t.Fatal(e) }
+		var d Certificate
+		if e := d.UnmarshalText(txt); e!=nil { t.Fatal(e) }
+		if d.N.Cmp(p)!=0 || d.Verify()!=nil { t.Fatalf("certificate of %v does not round-trip",p) }
+		again,_ := d.MarshalText()
+		if string(again)!=string(txt) { t.Fatalf("text form not stable:\n%s\n%s",txt,again) }
+	}
+	for _,n := range []int64{91,1<<40+1,1} {
+		if _,e := ProvePrime(big.NewInt(n)); e==nil { t.Errorf("ProvePrime(%d) succeeded",n) }
+	}
+	q := safePrime(1<<40)
+	if _,e := ProvePrime(new(big.Int).Mul(q,q)); e==nil { t.Error("ProvePrime succeeded on a square") }
+}
+
+func TestCertificateTampering(t *testing.T) {
+	p := safePrime(1<<40) // p-1 = 2*q
+	c,e := ProvePrime(p)
+	if e!=nil { t.Fatal(e) }
+	copyCert := func() *Certificate {
+		d := *c
+		d.Steps = append([]CertStep(nil),c.Steps...)
+		return &d
+	}
+	bad := copyCert()
+	bad.Steps[0].A = big.NewInt(1)
+	if bad.Verify()==nil { t.Error("witness 1 accepted") }
+	bad = copyCert()
+	bad.Steps[len(bad.Steps)-1].A = new(big.Int).Sub(p,big.NewInt(1))
+	if bad.Verify()==nil { t.Error("witness P-1 accepted") }
+	bad = copyCert()
+	bad.Steps = bad.Steps[:1] // F = 2, far below sqrt(p)
+	if e := bad.Verify(); e==nil { t.Error("insufficient F accepted") }
+	bad = copyCert()
+	bad.N = new(big.Int).Add(p,big.NewInt(2))
+	if bad.Verify()==nil { t.Error("certificate accepted for another number") }
+	bad = copyCert()
+	bad.Steps[len(bad.Steps)-1].Cert = nil
+	if bad.Verify()==nil { t.Error("step without sub-certificate accepted") }
+	for _,txt := range []string{"","zz 2^1:3","b 2^x:3","b 7^1:2 ffffffffff^1:2"} {
+		var d Certificate
+		if d.UnmarshalText([]byte(txt))==nil { t.Errorf("UnmarshalText accepted %q",txt) }
+	}
+}
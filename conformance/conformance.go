@@ -0,0 +1,266 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+/*
+Package conformance checks that an implementation of hypercomplex.Algebra is
+a drop-in replacement for Modulus: from a backend's own tests, call
+
+	func TestConformance(t *testing.T) {
+		conformance.Run(t,myAlgebra,8)
+	}
+
+Run checks the ring axioms on random elements (the algebra must be a
+commutative ring with one = [1,0,...,0]), Exp, Inverse and the encoding, and
+then runs key agreement, sealing, signatures and commitments over the
+Group of the algebra. If the algebra is a Modulus, *Fixed or *Tuned whose
+modulus and dimension match a registered parameter set, threshold ElGamal is
+checked too, with the algebra doing the arithmetic.
+
+Inverse is tried on random elements, which for a large modulus are units
+with overwhelming probability. It must return nil for zero, and for the
+likely zero divisors x, 1+x, 1+x*y (x, y being basis elements other than
+one) it must return either nil or a true inverse.
+*/
+package conformance
+
+import "github.com/mad-day/hypercomplex"
+import "crypto/rand"
+import "math/big"
+import "bytes"
+import "fmt"
+import "testing"
+
+// Number of random samples per property.
+const samples = 8
+
+// Runs the conformance suite for alg on elements of dimension dim.
+func Run(t *testing.T, alg hypercomplex.Algebra, dim int) {
+	t.Helper()
+	s := &suite{alg,dim}
+	t.Run("Axioms",s.axioms)
+	t.Run("Exp",s.exp)
+	t.Run("Inverse",s.inverse)
+	t.Run("Encoding",s.encoding)
+	t.Run("KeyAgreement",s.keyAgreement)
+	t.Run("Seal",s.seal)
+	t.Run("Signature",s.signature)
+	t.Run("Commitment",s.commitment)
+	t.Run("ElGamal",s.elgamal)
+}
+
+type suite struct{
+	alg hypercomplex.Algebra
+	dim int
+}
+
+func (s *suite) random(t *testing.T) hypercomplex.MultiComp {
+	a,e := s.alg.Deterministic(rand.Reader,s.dim)
+	if e!=nil { t.Fatal(e) }
+	if len(a)!=s.dim { t.Fatalf("Deterministic returned %d coefficients, want %d",len(a),s.dim) }
+	return a
+}
+
+func (s *suite) one() hypercomplex.MultiComp {
+	r := make(hypercomplex.MultiComp,s.dim)
+	for i := range r { r[i] = new(big.Int) }
+	r[0].SetUint64(1)
+	return r
+}
+
+func (s *suite) zero() hypercomplex.MultiComp {
+	return s.alg.Sub(s.one(),s.one())
+}
+
+func (s *suite) group(t *testing.T) hypercomplex.Group {
+	return hypercomplex.Group{Algebra:s.alg,G:s.random(t)}
+}
+
+func check(t *testing.T, what string, got,want hypercomplex.MultiComp) {
+	t.Helper()
+	if !got.Equal(want) { t.Fatalf("%s: got %v, want %v",what,got,want) }
+}
+
+func (s *suite) axioms(t *testing.T) {
+	A := s.alg
+	for i := 0; i<samples; i++ {
+		a,b,c := s.random(t),s.random(t),s.random(t)
+		check(t,"a+b = b+a",A.Add(a,b),A.Add(b,a))
+		check(t,"(a+b)+c = a+(b+c)",A.Add(A.Add(a,b),c),A.Add(a,A.Add(b,c)))
+		check(t,"a+0 = a",A.Add(a,s.zero()),a)
+		check(t,"a+(-a) = 0",A.Add(a,A.Neg(a)),s.zero())
+		check(t,"a-b = a+(-b)",A.Sub(a,b),A.Add(a,A.Neg(b)))
+		check(t,"a*b = b*a",A.Multiply(a,b),A.Multiply(b,a))
+		check(t,"(a*b)*c = a*(b*c)",A.Multiply(A.Multiply(a,b),c),A.Multiply(a,A.Multiply(b,c)))
+		check(t,"a*1 = a",A.Multiply(a,s.one()),a)
+		check(t,"a*(b+c) = a*b+a*c",A.Multiply(a,A.Add(b,c)),A.Add(A.Multiply(a,b),A.Multiply(a,c)))
+		check(t,"squaring",A.Multiply(a,a),A.Multiply(a,a.Copy()))
+	}
+}
+
+func (s *suite) exp(t *testing.T) {
+	A := s.alg
+	for i := 0; i<samples; i++ {
+		a := s.random(t)
+		x,y := make([]byte,8),make([]byte,8)
+		rand.Read(x)
+		rand.Read(y)
+		bx,by := new(big.Int).SetBytes(x),new(big.Int).SetBytes(y)
+		sum := new(big.Int).Add(bx,by).Bytes()
+		check(t,"a^0 = 1",A.Exp(a,nil),s.one())
+		check(t,"a^1 = a",A.Exp(a,[]byte{1}),a)
+		check(t,"a^3 = a*a*a",A.Exp(a,[]byte{0,3}),A.Multiply(a,A.Multiply(a,a)))
+		check(t,"a^(x+y) = a^x*a^y",A.Exp(a,sum),A.Multiply(A.Exp(a,x),A.Exp(a,y)))
+		check(t,"(a^x)^y = (a^y)^x",A.Exp(A.Exp(a,x),y),A.Exp(A.Exp(a,y),x))
+	}
+}
+
+func (s *suite) inverse(t *testing.T) {
+	A := s.alg
+	for i := 0; i<samples; i++ {
+		a := s.random(t)
+		inv := A.Inverse(a)
+		if inv==nil { t.Fatalf("no inverse for %v",a) }
+		check(t,"a*a^-1 = 1",A.Multiply(a,inv),s.one())
+	}
+	if A.Inverse(s.zero())!=nil { t.Fatal("inverse returned for zero") }
+	for _,a := range s.nonUnits() {
+		if inv := A.Inverse(a); inv!=nil { check(t,fmt.Sprintf("%v*%v^-1",a,a),A.Multiply(a,inv),s.one()) }
+	}
+}
+
+// Candidate non-units: basis elements e_i and 1+e_i, and 1+e_i*e_j.
+func (s *suite) nonUnits() []hypercomplex.MultiComp {
+	var r []hypercomplex.MultiComp
+	basis := func(i int) hypercomplex.MultiComp {
+		e := s.zero()
+		e[i] = big.NewInt(1)
+		return e
+	}
+	for i := 1; i<s.dim; i++ {
+		r = append(r,basis(i),s.alg.Add(s.one(),basis(i)))
+		for j := i+1; j<s.dim; j++ {
+			r = append(r,s.alg.Add(s.one(),s.alg.Multiply(basis(i),basis(j))))
+		}
+	}
+	return r
+}
+
+func (s *suite) encoding(t *testing.T) {
+	A := s.alg
+	for i := 0; i<samples; i++ {
+		a := s.random(t)
+		enc := A.Encode(a)
+		b,e := A.Decode(enc)
+		if e!=nil { t.Fatal(e) }
+		check(t,"Decode(Encode(a))",b,a)
+		if !bytes.Equal(A.Encode(b),enc) { t.Fatal("encoding is not canonical") }
+		if _,e := A.Decode(enc[:len(enc)-1]); e==nil { t.Fatal("truncated encoding accepted") }
+	}
+	if _,e := A.Decode(nil); e==nil { t.Fatal("empty encoding accepted") }
+}
+
+func (s *suite) keyAgreement(t *testing.T) {
+	g := s.group(t)
+	k1,e := g.GenerateKey(rand.Reader)
+	if e!=nil { t.Fatal(e) }
+	k2,e := g.GenerateKey(rand.Reader)
+	if e!=nil { t.Fatal(e) }
+	check(t,"DH",g.DH(k1.Private,k2.Public),g.DH(k2.Private,k1.Public))
+	d1,e := g.DeriveKeyPair([]byte("conformance secret"))
+	if e!=nil { t.Fatal(e) }
+	d2,_ := g.DeriveKeyPair([]byte("conformance secret"))
+	check(t,"DeriveKeyPair",d1.Public,d2.Public)
+}
+
+func (s *suite) seal(t *testing.T) {
+	g := s.group(t)
+	kp,e := g.GenerateKey(rand.Reader)
+	if e!=nil { t.Fatal(e) }
+	msg := []byte("conformance message")
+	sealed,e := g.Seal(rand.Reader,kp.Public,[]byte("info"),[]byte("aad"),msg)
+	if e!=nil { t.Fatal(e) }
+	pt,e := g.Open(kp,sealed,[]byte("info"),[]byte("aad"))
+	if e!=nil || !bytes.Equal(pt,msg) { t.Fatalf("Open failed: %v",e) }
+	if _,e := g.Open(kp,sealed,[]byte("info"),[]byte("other")); e==nil { t.Fatal("wrong aad accepted") }
+	sealed.Ciphertext[0] ^= 1
+	if _,e := g.Open(kp,sealed,[]byte("info"),[]byte("aad")); e==nil { t.Fatal("tampered ciphertext accepted") }
+}
+
+func (s *suite) signature(t *testing.T) {
+	g := s.group(t)
+	kp,e := g.GenerateKey(rand.Reader)
+	if e!=nil { t.Fatal(e) }
+	msg := []byte("conformance message")
+	sig,e := g.Sign(rand.Reader,kp,msg)
+	if e!=nil { t.Fatal(e) }
+	if !g.Verify(kp.Public,msg,sig) { t.Fatal("valid signature rejected") }
+	if g.Verify(kp.Public,[]byte("other message"),sig) { t.Fatal("signature accepted for another message") }
+	other,_ := g.GenerateKey(rand.Reader)
+	if g.Verify(other.Public,msg,sig) { t.Fatal("signature accepted for another key") }
+	sig.S.Add(sig.S,big.NewInt(1))
+	if g.Verify(kp.Public,msg,sig) { t.Fatal("tampered signature accepted") }
+}
+
+func (s *suite) commitment(t *testing.T) {
+	g := s.group(t)
+	p,e := g.Pedersen([]byte("conformance"))
+	if e!=nil { t.Fatal(e) }
+	m := big.NewInt(42)
+	c,r,e := p.Commit(rand.Reader,m)
+	if e!=nil { t.Fatal(e) }
+	if !p.Open(c,m,r) { t.Fatal("valid opening rejected") }
+	if p.Open(c,big.NewInt(43),r) { t.Fatal("opening to another message accepted") }
+	c2,_,_ := p.Commit(rand.Reader,m)
+	if c2.Equal(c) { t.Fatal("commitments are not randomised") }
+}
+
+func (s *suite) elgamal(t *testing.T) {
+	var m hypercomplex.Modulus
+	switch a := s.alg.(type) {
+	case hypercomplex.Modulus: m = a
+	case *hypercomplex.Fixed: m = a.Modulus
+	case *hypercomplex.Tuned: m = a.Modulus
+	default: t.Skip("not a multicomplex algebra")
+	}
+	var ps *hypercomplex.ParamSet
+	for _,n := range hypercomplex.ParamNames() {
+		p,_ := hypercomplex.Params(n)
+		if p.Dim==s.dim && p.Modulus.Mod.Cmp(m.Mod)==0 { ps = p }
+	}
+	if ps==nil { t.Skip("no parameter set for this modulus and dimension") }
+	sg,e := ps.Subgroup(rand.Reader)
+	if e!=nil { t.Fatal(e) }
+	sg.Algebra = s.alg
+	key,auths,e := sg.GenerateThreshold(rand.Reader,3,2)
+	if e!=nil { t.Fatal(e) }
+	c,r,e := sg.Encrypt(rand.Reader,key.Y,big.NewInt(1))
+	if e!=nil { t.Fatal(e) }
+	proof,e := sg.ProveBit(rand.Reader,key.Y,c,1,r)
+	if e!=nil { t.Fatal(e) }
+	if !sg.VerifyBit(key.Y,c,proof) { t.Fatal("bit proof rejected") }
+	pt,e := key.Decrypt(rand.Reader,sg.Add(c,c),auths[1:])
+	if e!=nil { t.Fatal(e) }
+	check(t,"Decrypt(E(1)+E(1))",pt,sg.Multiply(sg.G,sg.G))
+	zero,e := key.IsZero(rand.Reader,sg.Sub(c,c),auths)
+	if e!=nil || !zero { t.Fatalf("IsZero(E(1)-E(1)) = %v, %v",zero,e) }
+}
@@ -0,0 +1,44 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package conformance_test
+
+import "github.com/mad-day/hypercomplex"
+import "github.com/mad-day/hypercomplex/conformance"
+import "testing"
+
+func TestConformance(t *testing.T) {
+	for _,name := range hypercomplex.ParamNames() {
+		ps,e := hypercomplex.Params(name)
+		if e!=nil { t.Fatal(e) }
+		m := ps.Modulus
+		f,e := m.Fixed()
+		if e!=nil { t.Fatal(e) }
+		tu,e := m.Tune(ps.Dim)
+		if e!=nil { t.Fatal(e) }
+		t.Run(name+"/Modulus",func(t *testing.T) { conformance.Run(t,m,ps.Dim) })
+		t.Run(name+"/Fixed",func(t *testing.T) { conformance.Run(t,f,ps.Dim) })
+		t.Run(name+"/Tuned",func(t *testing.T) { conformance.Run(t,tu,ps.Dim) })
+		t.Run(name+"/Jet",func(t *testing.T) { conformance.Run(t,hypercomplex.Jet{Mod:m.Mod},ps.Dim+1) })
+	}
+}
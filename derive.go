@@ -0,0 +1,93 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math/big"
+import "crypto/sha3"
+import "encoding/binary"
+import "errors"
+
+/*
+Derivation of elements and scalars from plain strings, reproducible by
+anyone holding the same parameter set.
+
+The input is a SHAKE256 instance absorbing, with every field prefixed by
+its length as a big-endian uint32:
+
+	"hypercomplex/derive/v1"
+	kind                     "element" or "scalar"
+	P                        big-endian, ceil(bitlen(P)/8) bytes
+	Dim                      big-endian uint64
+	label
+	context
+	index                    big-endian uint64
+
+An element is read from the output by Deterministic(xof, Dim): every
+coefficient takes as many bytes as P-1 and lies in 1..P-1. It is an element
+of the ring, not necessarily of the subgroup of the parameter set. A scalar
+is read as ceil(bitlen(Order)/8)+16 bytes, big-endian, reduced modulo Order.
+*/
+const deriveDomain = "hypercomplex/derive/v1"
+
+func deriveXOF(ps *ParamSet, kind string, label, context []byte, index uint64) (*sha3.SHAKE,error) {
+	if ps==nil || ps.Modulus.Mod==nil || ps.Dim<1 { return nil,errors.New("Invalid parameter set") }
+	dim := binary.BigEndian.AppendUint64(nil,uint64(ps.Dim))
+	p := ps.Modulus.Mod.FillBytes(make([]byte,ps.Modulus.coefLen()))
+	return newXOF(kind,[][]byte{p,dim},label,context,index),nil
+}
+
+func newXOF(kind string, id [][]byte, label, context []byte, index uint64) *sha3.SHAKE {
+	parts := append([][]byte{[]byte(deriveDomain),[]byte(kind)},id...)
+	parts = append(parts,label,context,binary.BigEndian.AppendUint64(nil,index))
+	x := sha3.NewSHAKE256()
+	x.Write(frame(parts...))
+	return x
+}
+
+// Derives an element of dimension ps.Dim from label, context and index.
+func DeriveElement(ps *ParamSet, label, context []byte, index uint64) (MultiComp,error) {
+	x,e := deriveXOF(ps,"element",label,context,index)
+	if e!=nil { return nil,e }
+	return ps.Modulus.Deterministic(x,ps.Dim)
+}
+
+/*
+Derives an element for a Group without a parameter set, with the same
+framing, except that the kind is "group element" and P and Dim are replaced by
+the one field Encode(G).
+*/
+func (g Group) Derive(label, context []byte, index uint64) (MultiComp,error) {
+	x := newXOF("group element",[][]byte{g.Encode(g.G)},label,context,index)
+	return g.Deterministic(x,len(g.G))
+}
+
+// Derives a scalar modulo ps.Order from label, context and index.
+func DeriveScalar(ps *ParamSet, label, context []byte, index uint64) (*big.Int,error) {
+	if ps==nil || ps.Order==nil || ps.Order.Sign()<=0 { return nil,errors.New("Parameter set has no order") }
+	x,e := deriveXOF(ps,"scalar",label,context,index)
+	if e!=nil { return nil,e }
+	buf := make([]byte,(ps.Order.BitLen()+7)/8+16)
+	x.Read(buf)
+	return new(big.Int).Mod(new(big.Int).SetBytes(buf),ps.Order),nil
+}
@@ -0,0 +1,93 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+
+func hexInt(s string) *big.Int {
+	x,_ := new(big.Int).SetString(s,16)
+	return x
+}
+
+// Known answers for "hc256-4", computed independently from the documented framing.
+var deriveVectors = []struct{
+	label,context string
+	index uint64
+	element [4]string
+	scalar string
+}{
+	{"gen","ctx",0,[4]string{
+		"681cd35dd7da294de8bc9e91b32c62177e921c9d5ab1cf3a2306cae4449ade57",
+		"7a085040c4f82e7be05b86c77c9f59b5f16df3586c6b263d0ef294ca6cd35463",
+		"4d145b56126a8ceedf613f0348288634b7501d58bb9bca613d32536c8c144da9",
+		"572f49ba9e30425510a81b4678f1c0bab0b5e797eae3b601444d374e394cde2",
+	},"828faee54913ec29ac11e3be069945faab384ddfb1869d8203d2f73eb5d4e4"},
+	{"gen","ctx",1,[4]string{
+		"42fd0f10c9118f9d23b3623bceb6e44aa4c083d2d3424013fb097f4b06d4c53",
+		"8ae5ce53dc1c9f4663f920be5aaf05574dc709d7c780fa8d4c9fe808a124c8f7",
+		"388b8d2721cdad39f1c6453dc67178266b18016745f8e316bd1a24af720c6450",
+		"90a7c7f671abdd5e0bffa45a02c318ab979e0b54e232051808a73af57be19031",
+	},"823b9870e6c98b666147b0bf293b6aa769d475b14df49114b13e7e725ed479"},
+	{"","",7,[4]string{
+		"5eeba6d18f5c83d383a280a619d81c874475ea78f810a882a1084b84f9be120b",
+		"3bce663f49620ee792cf346353ddd9e023612e84fe511bf598cc1e0239a0f1df",
+		"6af43e45edfaac5e9f2c2adb486380bcd1d22669da62a8e480d056575c5cecd8",
+		"1da08ddb7db83fd26e831baa010b8937374090fb97871a43f0d181385b50e22d",
+	},"19fd65af11a383c1d8d96968cde6f3cd3647595faf3d5332b9c5ff10dd1242e"},
+}
+
+func TestDeriveVectors(t *testing.T) {
+	ps,e := Params("hc256-4")
+	if e!=nil { t.Fatal(e) }
+	for _,v := range deriveVectors {
+		a,e := DeriveElement(ps,[]byte(v.label),[]byte(v.context),v.index)
+		if e!=nil { t.Fatal(e) }
+		want := make(MultiComp,4)
+		for i,s := range v.element { want[i] = hexInt(s) }
+		if !a.Equal(want) { t.Errorf("DeriveElement(%q,%q,%d) = %v, want %v",v.label,v.context,v.index,a,want) }
+		s,e := DeriveScalar(ps,[]byte(v.label),[]byte(v.context),v.index)
+		if e!=nil { t.Fatal(e) }
+		if s.Cmp(hexInt(v.scalar))!=0 { t.Errorf("DeriveScalar(%q,%q,%d) = %x, want %s",v.label,v.context,v.index,s,v.scalar) }
+	}
+}
+
+func TestDeriveSeparation(t *testing.T) {
+	ps,_ := Params("hc256-4")
+	g0,_ := DeriveElement(ps,[]byte("base"),nil,0)
+	g := Group{Algebra:ps.Modulus,G:g0}
+	seen := make(map[string]string)
+	for _,c := range []struct{ label,context string }{{"gen","ctx"},{"ge","nctx"},{"genctx",""},{"","genctx"}} {
+		a,_ := DeriveElement(ps,[]byte(c.label),[]byte(c.context),0)
+		b,_ := g.Derive([]byte(c.label),[]byte(c.context),0)
+		b2,_ := g.Derive([]byte(c.label),[]byte(c.context),0)
+		if !b.Equal(b2) { t.Fatal("Group.Derive is not deterministic") }
+		for _,x := range []MultiComp{a,b} {
+			if prev,ok := seen[x.String()]; ok { t.Errorf("%q,%q collides with %s",c.label,c.context,prev) }
+			seen[x.String()] = c.label+"|"+c.context
+		}
+	}
+	if _,e := DeriveElement(nil,nil,nil,0); e==nil { t.Error("nil parameter set accepted") }
+	if _,e := DeriveScalar(&ParamSet{Modulus:ps.Modulus,Dim:4},nil,nil,0); e==nil { t.Error("parameter set without order accepted") }
+}
@@ -0,0 +1,131 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "crypto/aes"
+import "crypto/cipher"
+import "crypto/hkdf"
+import "crypto/sha256"
+import "encoding/binary"
+import "io"
+import "errors"
+
+/*
+A Diffie-Hellman group: the powers of the generator G in an Algebra. Private
+keys are byte strings used directly as exponents (see Modulus.Exp).
+*/
+type Group struct{
+	Algebra
+	G MultiComp
+}
+
+type KeyPair struct{
+	Private []byte
+	Public MultiComp
+}
+
+// Length of a private key: twice the length of P, enough for the P^2-1 units.
+func (g Group) privLen() int { return 2*len(g.Encode(g.G))/len(g.G) }
+
+func (g Group) keyPair(priv []byte) *KeyPair {
+	return &KeyPair{Private:priv,Public:g.Exp(g.G,priv)}
+}
+
+func (g Group) GenerateKey(rand io.Reader) (*KeyPair,error) {
+	priv := make([]byte,g.privLen())
+	if _,e := io.ReadFull(rand,priv); e!=nil { return nil,e }
+	return g.keyPair(priv),nil
+}
+
+// Derives a key pair from a uniformly random secret.
+func (g Group) DeriveKeyPair(secret []byte) (*KeyPair,error) {
+	priv,e := hkdf.Key(sha256.New,secret,nil,"hypercomplex dh key",g.privLen())
+	if e!=nil { return nil,e }
+	return g.keyPair(priv),nil
+}
+
+// Computes the shared element pub^priv.
+func (g Group) DH(priv []byte, pub MultiComp) MultiComp {
+	return g.Exp(pub,priv)
+}
+
+/*
+A message sealed to a public key: the ephemeral public key and the AES-GCM
+ciphertext.
+*/
+type Sealed struct{
+	Enc MultiComp
+	Ciphertext []byte
+}
+
+/*
+Derives key and nonce of a sealed message. The shared element, the ephemeral
+and the recipient public key enter HKDF-Extract; 'info' binds the message to
+its context.
+*/
+func (g Group) sealKey(shared,enc,pub MultiComp, info []byte) (cipher.AEAD,[]byte,error) {
+	ikm := g.Encode(shared)
+	salt := append(g.Encode(enc),g.Encode(pub)...)
+	prk,e := hkdf.Extract(sha256.New,ikm,salt)
+	if e!=nil { return nil,nil,e }
+	key,e := hkdf.Expand(sha256.New,prk,string(frame([]byte("hypercomplex seal key"),info)),32)
+	if e!=nil { return nil,nil,e }
+	nonce,e := hkdf.Expand(sha256.New,prk,string(frame([]byte("hypercomplex seal nonce"),info)),12)
+	if e!=nil { return nil,nil,e }
+	b,e := aes.NewCipher(key)
+	if e!=nil { return nil,nil,e }
+	aead,e := cipher.NewGCM(b)
+	if e!=nil { return nil,nil,e }
+	return aead,nonce,nil
+}
+
+/*
+Encrypts plaintext to pub, in the style of HPKE base mode: a fresh ephemeral
+key pair, DH with the recipient, a key schedule and an AEAD.
+*/
+func (g Group) Seal(rand io.Reader, pub MultiComp, info, aad, plaintext []byte) (*Sealed,error) {
+	eph,e := g.GenerateKey(rand)
+	if e!=nil { return nil,e }
+	aead,nonce,e := g.sealKey(g.DH(eph.Private,pub),eph.Public,pub,info)
+	if e!=nil { return nil,e }
+	return &Sealed{Enc:eph.Public,Ciphertext:aead.Seal(nil,nonce,plaintext,aad)},nil
+}
+
+// Decrypts a message sealed to kp.Public.
+func (g Group) Open(kp *KeyPair, s *Sealed, info, aad []byte) ([]byte,error) {
+	if len(s.Enc)!=len(g.G) { return nil,errors.New("Invalid ephemeral key") }
+	aead,nonce,e := g.sealKey(g.DH(kp.Private,s.Enc),s.Enc,kp.Public,info)
+	if e!=nil { return nil,e }
+	return aead.Open(nil,nonce,s.Ciphertext,aad)
+}
+
+// Length-prefixed concatenation, for unambiguous KDF inputs.
+func frame(parts ...[]byte) []byte {
+	var b []byte
+	for _,p := range parts {
+		b = binary.BigEndian.AppendUint32(b,uint32(len(p)))
+		b = append(b,p...)
+	}
+	return b
+}
@@ -0,0 +1,147 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package hypercomplex
+
+import "math/big"
+import "errors"
+
+/*
+The idempotent decomposition ("diagonal transform") of the multicomplex ring.
+
+Let 'a = u + v*j' where j is the topmost unit and s is a square root of -1
+commuting with j. With the idempotents e+ = (1-s*j)/2 and e- = (1+s*j)/2 one has
+
+	a = (u + s*v)*e+ + (u - s*v)*e-
+
+and both parts multiply independently. Applied recursively, a MultiComp
+falls apart into components that multiply pointwise.
+
+If P is a prime with P = 1 (mod 4), s is a square root of -1 modulo P and all
+components are scalars. Otherwise s is the lowest unit i1, and the components
+are Gaussian numbers x + y*i1 (coefficient pairs).
+*/
+type splitting struct{
+	m Modulus
+	s *big.Int // nil: s = i1
+	half *big.Int
+}
+
+func (m Modulus) splitting() (*splitting,error) {
+	if m.Mod.Bit(0)==0 { return nil,errors.New("Modulus must be odd") }
+	d := &splitting{m:m}
+	d.half = new(big.Int).ModInverse(big.NewInt(2),m.Mod)
+	if m.Mod.Bit(1)==0 && m.Mod.ProbablyPrime(20) {
+		d.s = new(big.Int).ModSqrt(new(big.Int).Sub(m.Mod,big.NewInt(1)),m.Mod)
+	}
+	return d,nil
+}
+
+// Number of coefficients per component.
+func (d *splitting) width(n int) int {
+	if d.s!=nil || n<2 { return 1 }
+	return 2
+}
+
+// Multiplies by s.
+func (d *splitting) mulS(v MultiComp) MultiComp {
+	r := make(MultiComp,len(v))
+	if d.s!=nil {
+		for i,c := range v {
+			r[i] = new(big.Int).Mul(c,d.s)
+			r[i].Mod(r[i],d.m.Mod)
+		}
+		return r
+	}
+	for i := 0; i<len(v); i+=2 {
+		r[i] = new(big.Int).Neg(v[i+1])
+		r[i].Mod(r[i],d.m.Mod)
+		r[i+1] = v[i]
+	}
+	return r
+}
+func (d *splitting) scale(v MultiComp, k *big.Int) MultiComp {
+	r := make(MultiComp,len(v))
+	for i,c := range v {
+		r[i] = new(big.Int).Mul(c,k)
+		r[i].Mod(r[i],d.m.Mod)
+	}
+	return r
+}
+
+// Transforms a into its components, with coefficients reduced modulo P.
+func (d *splitting) split(a MultiComp) MultiComp {
+	L := len(a)/2
+	if L<d.width(len(a)) {
+		c := make(MultiComp,len(a))
+		for i,x := range a { c[i] = new(big.Int).Mod(x,d.m.Mod) }
+		return c
+	}
+	u := a[:L]
+	sv := d.mulS(a[L:])
+	return append(d.split(d.m.Add(u,sv)),d.split(d.m.Sub(u,sv))...)
+}
+
+// Inverse of split.
+func (d *splitting) join(c MultiComp) MultiComp {
+	L := len(c)/2
+	if L<d.width(len(c)) { return c.Copy() }
+	A := d.join(c[:L])
+	B := d.join(c[L:])
+	u := d.scale(d.m.Add(A,B),d.half)
+	v := d.mulS(d.scale(d.m.Sub(B,A),d.half))
+	return append(u,v...)
+}
+
+// Pointwise product of two split elements.
+func (d *splitting) mul(a,b MultiComp) MultiComp {
+	if d.width(len(a))==1 {
+		c := make(MultiComp,len(a))
+		for i := range c {
+			c[i] = new(big.Int).Mul(a[i],b[i])
+			c[i].Mod(c[i],d.m.Mod)
+		}
+		return c
+	}
+	c := make(MultiComp,0,len(a))
+	for i := 0; i<len(a); i+=2 {
+		c = append(c,d.m.Multiply(a[i:i+2],b[i:i+2])...)
+	}
+	return c
+}
+
+// Pointwise power of a split element.
+func (d *splitting) exp(a MultiComp, exp []byte) MultiComp {
+	if d.width(len(a))==1 {
+		e := new(big.Int).SetBytes(exp)
+		c := make(MultiComp,len(a))
+		for i := range c { c[i] = new(big.Int).Exp(a[i],e,d.m.Mod) }
+		return c
+	}
+	c := make(MultiComp,0,len(a))
+	for i := 0; i<len(a); i+=2 {
+		c = append(c,d.m.Exp(a[i:i+2],exp)...)
+	}
+	return c
+}
@@ -0,0 +1,387 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math/big"
+import "crypto/sha512"
+import "io"
+import "fmt"
+import "errors"
+
+/*
+A subgroup of prime order Q of the multicomplex units, generated by G.
+Exponents are taken modulo Q, which makes it usable for ElGamal and
+Sigma protocols.
+*/
+type Subgroup struct{
+	Group
+	Q *big.Int
+	cof *big.Int // exponent of the unit group divided by Q
+}
+
+/*
+Derives a generator of order Q for elements of dimension dim, from the
+entropy source (see Deterministic). P must be prime and Q must divide the
+exponent of the unit group.
+*/
+func (m Modulus) Subgroup(source io.Reader, dim int, q *big.Int) (*Subgroup,error) {
+	lambda := m.unitExponent(dim)
+	cof,r := new(big.Int).QuoRem(lambda,q,new(big.Int))
+	if r.Sign()!=0 { return nil,errors.New("Order does not divide the order of the unit group") }
+	for i := 0; i<64; i++ {
+		h,e := m.Deterministic(source,dim)
+		if e!=nil { return nil,e }
+		g := m.Exp(h,cof.Bytes())
+		// A zero divisor h gives a g that is not of order Q.
+		if !isOne(g) && isOne(m.Exp(g,q.Bytes())) { return &Subgroup{Group{m,g},new(big.Int).Set(q),cof},nil }
+	}
+	return nil,errors.New("No generator found")
+}
+
+// The subgroup of a parameter set.
+func (ps *ParamSet) Subgroup(source io.Reader) (*Subgroup,error) {
+	return ps.Modulus.Subgroup(source,ps.Dim,ps.Order)
+}
+
+func isOne(a MultiComp) bool {
+	return a[0].Cmp(big.NewInt(1))==0 && isZero(a[1:])
+}
+func (sg *Subgroup) one() MultiComp {
+	r := zeroes(len(sg.G))
+	r[0].SetUint64(1)
+	return r
+}
+
+// x^e, with e taken modulo Q.
+func (sg *Subgroup) pow(x MultiComp, e *big.Int) MultiComp {
+	return sg.Exp(x,new(big.Int).Mod(e,sg.Q).Bytes())
+}
+
+// Reports whether x is an element of the subgroup.
+func (sg *Subgroup) Contains(x MultiComp) bool {
+	if len(x)!=len(sg.G) { return false }
+	for _,c := range x {
+		if c==nil || c.Sign()<0 { return false }
+	}
+	if y,e := sg.Decode(sg.Encode(x)); e!=nil || !y.Equal(x) { return false }
+	return isOne(sg.Exp(x,sg.Q.Bytes()))
+}
+
+func (sg *Subgroup) RandomScalar(rand io.Reader) (*big.Int,error) {
+	buf := make([]byte,(sg.Q.BitLen()+7)/8+16)
+	if _,e := io.ReadFull(rand,buf); e!=nil { return nil,e }
+	return new(big.Int).Mod(new(big.Int).SetBytes(buf),sg.Q),nil
+}
+
+// Fiat-Shamir challenge over a domain label and the given elements.
+func (sg *Subgroup) challenge(label string, xs ...MultiComp) *big.Int {
+	parts := [][]byte{[]byte(label)}
+	for _,x := range xs { parts = append(parts,sg.Encode(x)) }
+	h := sha512.Sum512(frame(parts...))
+	return new(big.Int).Mod(new(big.Int).SetBytes(h[:]),sg.Q)
+}
+
+// An exponential ElGamal ciphertext (g^r, g^m * Y^r).
+type Ciphertext struct{
+	A,B MultiComp
+}
+
+// Encrypts g^m to y. Returns the randomness too, for proofs.
+func (sg *Subgroup) Encrypt(rand io.Reader, y MultiComp, m *big.Int) (Ciphertext,*big.Int,error) {
+	r,e := sg.RandomScalar(rand)
+	if e!=nil { return Ciphertext{},nil,e }
+	return Ciphertext{sg.pow(sg.G,r),sg.Multiply(sg.pow(sg.G,m),sg.pow(y,r))},r,nil
+}
+
+// Encryption of g^m with no randomness; a public constant.
+func (sg *Subgroup) Trivial(m *big.Int) Ciphertext {
+	return Ciphertext{sg.one(),sg.pow(sg.G,m)}
+}
+
+// Encrypts the sum of the plaintexts.
+func (sg *Subgroup) Add(c,d Ciphertext) Ciphertext {
+	return Ciphertext{sg.Multiply(c.A,d.A),sg.Multiply(c.B,d.B)}
+}
+
+// Encrypts k times the plaintext.
+func (sg *Subgroup) Scale(c Ciphertext, k *big.Int) Ciphertext {
+	return Ciphertext{sg.pow(c.A,k),sg.pow(c.B,k)}
+}
+
+// Encrypts the difference of the plaintexts.
+func (sg *Subgroup) Sub(c,d Ciphertext) Ciphertext {
+	return sg.Add(c,sg.Scale(d,new(big.Int).Sub(sg.Q,big.NewInt(1))))
+}
+
+// Re-encrypts c with fresh randomness.
+func (sg *Subgroup) Rerandomize(rand io.Reader, y MultiComp, c Ciphertext) (Ciphertext,error) {
+	z,_,e := sg.Encrypt(rand,y,new(big.Int))
+	if e!=nil { return Ciphertext{},e }
+	return sg.Add(c,z),nil
+}
+
+func (sg *Subgroup) validCiphertext(c Ciphertext) bool {
+	return sg.Contains(c.A) && sg.Contains(c.B)
+}
+
+/*
+A Chaum-Pedersen proof that log_g1(h1) = log_g2(h2), in the compact form
+(challenge, response).
+*/
+type DLEQProof struct{
+	C,S *big.Int
+}
+
+func (sg *Subgroup) proveDLEQ(rand io.Reader, label string, g1,h1,g2,h2 MultiComp, x *big.Int) (*DLEQProof,error) {
+	w,e := sg.RandomScalar(rand)
+	if e!=nil { return nil,e }
+	c := sg.challenge(label,g1,h1,g2,h2,sg.pow(g1,w),sg.pow(g2,w))
+	s := new(big.Int).Mul(c,x)
+	s.Add(s,w).Mod(s,sg.Q)
+	return &DLEQProof{c,s},nil
+}
+
+// t = g^s * h^-c
+func (sg *Subgroup) commitment(g,h MultiComp, c,s *big.Int) MultiComp {
+	return sg.Multiply(sg.pow(g,s),sg.pow(h,new(big.Int).Sub(sg.Q,c)))
+}
+
+func (sg *Subgroup) verifyDLEQ(label string, g1,h1,g2,h2 MultiComp, p *DLEQProof) bool {
+	if p==nil || p.C==nil || p.S==nil { return false }
+	t1 := sg.commitment(g1,h1,p.C,p.S)
+	t2 := sg.commitment(g2,h2,p.C,p.S)
+	return sg.challenge(label,g1,h1,g2,h2,t1,t2).Cmp(p.C)==0
+}
+
+/*
+A disjunctive Chaum-Pedersen (CDS) proof that a ciphertext encrypts 0 or 1.
+Branch b proves (A, B/g^b) = (g^r, Y^r).
+*/
+type BitProof struct{
+	C0,C1,S0,S1 *big.Int
+}
+
+func (sg *Subgroup) ProveBit(rand io.Reader, y MultiComp, c Ciphertext, bit int, r *big.Int) (*BitProof,error) {
+	Bs := [2]MultiComp{c.B,sg.Multiply(c.B,sg.Inverse(sg.G))}
+	var cs,ss [2]*big.Int
+	var t1,t2 [2]MultiComp
+	k := 1-bit
+	var e error
+	if cs[k],e = sg.RandomScalar(rand); e!=nil { return nil,e }
+	if ss[k],e = sg.RandomScalar(rand); e!=nil { return nil,e }
+	t1[k] = sg.commitment(sg.G,c.A,cs[k],ss[k])
+	t2[k] = sg.commitment(y,Bs[k],cs[k],ss[k])
+	w,e := sg.RandomScalar(rand)
+	if e!=nil { return nil,e }
+	t1[bit],t2[bit] = sg.pow(sg.G,w),sg.pow(y,w)
+	ch := sg.challenge("bit",y,c.A,c.B,t1[0],t2[0],t1[1],t2[1])
+	cs[bit] = new(big.Int).Sub(ch,cs[k])
+	cs[bit].Mod(cs[bit],sg.Q)
+	ss[bit] = new(big.Int).Mul(cs[bit],r)
+	ss[bit].Add(ss[bit],w).Mod(ss[bit],sg.Q)
+	return &BitProof{cs[0],cs[1],ss[0],ss[1]},nil
+}
+
+func (sg *Subgroup) VerifyBit(y MultiComp, c Ciphertext, p *BitProof) bool {
+	if p==nil || p.C0==nil || p.C1==nil || p.S0==nil || p.S1==nil { return false }
+	if !sg.validCiphertext(c) { return false }
+	Bs := [2]MultiComp{c.B,sg.Multiply(c.B,sg.Inverse(sg.G))}
+	cs := [2]*big.Int{p.C0,p.C1}
+	ss := [2]*big.Int{p.S0,p.S1}
+	var t1,t2 [2]MultiComp
+	for b := 0; b<2; b++ {
+		t1[b] = sg.commitment(sg.G,c.A,cs[b],ss[b])
+		t2[b] = sg.commitment(y,Bs[b],cs[b],ss[b])
+	}
+	ch := sg.challenge("bit",y,c.A,c.B,t1[0],t2[0],t1[1],t2[1])
+	sum := new(big.Int).Add(p.C0,p.C1)
+	return sum.Mod(sum,sg.Q).Cmp(ch)==0
+}
+
+/*
+A t-of-n threshold ElGamal key. Y is the public key; VK[j-1] = g^x_j is the
+verification key of the share of authority j.
+*/
+type ThresholdKey struct{
+	*Subgroup
+	Y MultiComp
+	VK []MultiComp
+	T int
+}
+
+// An authority holding one share x_j of the threshold key.
+type Authority struct{
+	Index int // 1-based
+	Key *ThresholdKey
+	share *big.Int
+}
+
+/*
+Runs a Feldman-VSS based distributed key generation among n simulated
+authorities: each one deals a random polynomial of degree t-1, checks the
+shares it receives against the dealers' commitments, and adds them up.
+*/
+func (sg *Subgroup) GenerateThreshold(rand io.Reader, n, t int) (*ThresholdKey,[]*Authority,error) {
+	if t<1 || t>n { return nil,nil,errors.New("Invalid threshold") }
+	coefs := make([][]*big.Int,n)
+	commits := make([][]MultiComp,n)
+	for i := range coefs {
+		for k := 0; k<t; k++ {
+			a,e := sg.RandomScalar(rand)
+			if e!=nil { return nil,nil,e }
+			coefs[i] = append(coefs[i],a)
+			commits[i] = append(commits[i],sg.pow(sg.G,a))
+		}
+	}
+	key := &ThresholdKey{Subgroup:sg,Y:sg.one(),T:t}
+	for i := range commits { key.Y = sg.Multiply(key.Y,commits[i][0]) }
+	auths := make([]*Authority,n)
+	for j := 1; j<=n; j++ {
+		x := new(big.Int)
+		vk := sg.one()
+		for i := range coefs {
+			s := polyEval(coefs[i],j,sg.Q)
+			expect := sg.feldman(commits[i],j)
+			if !sg.pow(sg.G,s).Equal(expect) { return nil,nil,fmt.Errorf("Dealer %d sent authority %d a bad share",i+1,j) }
+			x.Add(x,s)
+			vk = sg.Multiply(vk,expect)
+		}
+		auths[j-1] = &Authority{Index:j,Key:key,share:x.Mod(x,sg.Q)}
+		key.VK = append(key.VK,vk)
+	}
+	return key,auths,nil
+}
+
+func polyEval(coefs []*big.Int, x int, q *big.Int) *big.Int {
+	r := new(big.Int)
+	bx := big.NewInt(int64(x))
+	for k := len(coefs)-1; k>=0; k-- {
+		r.Mul(r,bx).Add(r,coefs[k]).Mod(r,q)
+	}
+	return r
+}
+
+// prod_k C_k^(j^k), which equals g^f(j).
+func (sg *Subgroup) feldman(commits []MultiComp, j int) MultiComp {
+	r := sg.one()
+	jk := big.NewInt(1)
+	for _,c := range commits {
+		r = sg.Multiply(r,sg.pow(c,jk))
+		jk = new(big.Int).Mul(jk,big.NewInt(int64(j)))
+	}
+	return r
+}
+
+// A decryption share A^x_j with its proof.
+type PartialDecryption struct{
+	Index int
+	D MultiComp
+	Proof *DLEQProof
+}
+
+func (a *Authority) PartialDecrypt(rand io.Reader, c Ciphertext) (*PartialDecryption,error) {
+	sg := a.Key.Subgroup
+	d := sg.pow(c.A,a.share)
+	p,e := sg.proveDLEQ(rand,"decrypt",sg.G,a.Key.VK[a.Index-1],c.A,d,a.share)
+	if e!=nil { return nil,e }
+	return &PartialDecryption{a.Index,d,p},nil
+}
+
+/*
+Checks T decryption shares of c and combines them by Lagrange interpolation
+in the exponent. Returns g^m.
+*/
+func (k *ThresholdKey) Combine(c Ciphertext, parts []*PartialDecryption) (MultiComp,error) {
+	if len(parts)<k.T { return nil,errors.New("Not enough decryption shares") }
+	if !k.validCiphertext(c) { return nil,errors.New("Invalid ciphertext") }
+	parts = parts[:k.T]
+	seen := make(map[int]bool)
+	for _,p := range parts {
+		if p.Index<1 || p.Index>len(k.VK) || seen[p.Index] { return nil,errors.New("Invalid share index") }
+		seen[p.Index] = true
+		// The proof is only sound inside the subgroup.
+		if !k.Contains(p.D) || !k.verifyDLEQ("decrypt",k.G,k.VK[p.Index-1],c.A,p.D,p.Proof) {
+			return nil,fmt.Errorf("Bad decryption share from authority %d",p.Index)
+		}
+	}
+	ax := k.one()
+	for _,p := range parts {
+		num,den := big.NewInt(1),big.NewInt(1)
+		for _,o := range parts {
+			if o.Index==p.Index { continue }
+			num.Mul(num,big.NewInt(int64(o.Index)))
+			den.Mul(den,big.NewInt(int64(o.Index-p.Index)))
+		}
+		l := num.Mul(num,new(big.Int).ModInverse(den.Mod(den,k.Q),k.Q))
+		ax = k.Multiply(ax,k.pow(p.D,l))
+	}
+	return k.Multiply(c.B,k.Inverse(ax)),nil
+}
+
+// Jointly decrypts c with the first T authorities.
+func (k *ThresholdKey) Decrypt(rand io.Reader, c Ciphertext, auths []*Authority) (MultiComp,error) {
+	if len(auths)<k.T { return nil,errors.New("Not enough authorities") }
+	var parts []*PartialDecryption
+	for _,a := range auths[:k.T] {
+		p,e := a.PartialDecrypt(rand,c)
+		if e!=nil { return nil,e }
+		parts = append(parts,p)
+	}
+	return k.Combine(c,parts)
+}
+
+/*
+Raises c to a secret random power z, with a proof that both halves got the
+same exponent. A zero plaintext stays zero; any other one becomes random.
+*/
+func (a *Authority) Blind(rand io.Reader, c Ciphertext) (Ciphertext,*DLEQProof,error) {
+	sg := a.Key.Subgroup
+	z,e := sg.RandomScalar(rand)
+	if e!=nil { return Ciphertext{},nil,e }
+	for z.Sign()==0 {
+		if z,e = sg.RandomScalar(rand); e!=nil { return Ciphertext{},nil,e }
+	}
+	d := sg.Scale(c,z)
+	p,e := sg.proveDLEQ(rand,"blind",c.A,d.A,c.B,d.B,z)
+	return d,p,e
+}
+
+/*
+Decides whether c encrypts zero, revealing nothing else: every authority
+blinds c in turn, then the result is decrypted.
+*/
+func (k *ThresholdKey) IsZero(rand io.Reader, c Ciphertext, auths []*Authority) (bool,error) {
+	if !k.validCiphertext(c) { return false,errors.New("Invalid ciphertext") }
+	for _,a := range auths {
+		d,p,e := a.Blind(rand,c)
+		if e!=nil { return false,e }
+		if isOne(d.A) && !isOne(c.A) || !k.validCiphertext(d) || !k.verifyDLEQ("blind",c.A,d.A,c.B,d.B,p) {
+			return false,fmt.Errorf("Bad blinding from authority %d",a.Index)
+		}
+		c = d
+	}
+	m,e := k.Decrypt(rand,c,auths)
+	if e!=nil { return false,e }
+	return isOne(m),nil
+}
@@ -0,0 +1,142 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "bytes"
+import "math/big"
+import "math/rand"
+
+func testSubgroup(t *testing.T) (*Subgroup,*rand.Rand) {
+	ps,e := Params("hc256-4")
+	if e!=nil { t.Fatal(e) }
+	rng := rand.New(rand.NewSource(4))
+	sg,e := ps.Subgroup(rng)
+	if e!=nil { t.Fatal(e) }
+	return sg,rng
+}
+
+func TestSubgroup(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	if !sg.Contains(sg.G) || isOne(sg.G) { t.Fatal("generator not in the subgroup") }
+	if !isOne(sg.pow(sg.G,sg.Q)) { t.Fatal("G^Q != 1") }
+	x,_ := sg.Deterministic(rng,4)
+	if sg.Contains(x) { t.Error("random element taken for a subgroup element") }
+	if sg.Contains(sg.G[:2]) { t.Error("element of the wrong dimension accepted") }
+	m := sg.Algebra.(Modulus)
+	wide := append(MultiComp{new(big.Int).Add(sg.G[0],m.Mod)},sg.G[1:]...)
+	if sg.Contains(wide) { t.Error("unreduced coefficient accepted") }
+	if _,e := m.Subgroup(rng,4,new(big.Int).Add(sg.Q,big.NewInt(2))); e==nil { t.Error("order not dividing the unit group accepted") }
+}
+
+func TestThresholdDecrypt(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	if _,_,e := sg.GenerateThreshold(rng,3,4); e==nil { t.Error("threshold above n accepted") }
+	key,auths,e := sg.GenerateThreshold(rng,5,3)
+	if e!=nil { t.Fatal(e) }
+	for j,a := range auths {
+		if !sg.pow(sg.G,a.share).Equal(key.VK[j]) { t.Fatalf("verification key %d does not match the share",j+1) }
+	}
+	c,_,e := sg.Encrypt(rng,key.Y,big.NewInt(7))
+	if e!=nil { t.Fatal(e) }
+	want := sg.pow(sg.G,big.NewInt(7))
+	for _,set := range [][]int{{0,1,2},{2,3,4},{4,0,3}} {
+		var parts []*PartialDecryption
+		for _,i := range set {
+			p,e := auths[i].PartialDecrypt(rng,c)
+			if e!=nil { t.Fatal(e) }
+			parts = append(parts,p)
+		}
+		m,e := key.Combine(c,parts)
+		if e!=nil || !m.Equal(want) { t.Fatalf("authorities %v: %v, %v",set,m,e) }
+		if _,e := key.Combine(c,parts[:2]); e==nil { t.Error("two shares accepted for threshold 3") }
+		bad := *parts[1]
+		bad.D = sg.Multiply(bad.D,sg.G)
+		if _,e := key.Combine(c,[]*PartialDecryption{parts[0],&bad,parts[2]}); e==nil { t.Error("bad decryption share accepted") }
+		if _,e := key.Combine(c,[]*PartialDecryption{parts[0],parts[0],parts[2]}); e==nil { t.Error("duplicate share accepted") }
+	}
+	// Homomorphic sum: 7 + 7 - 7*2 = 0.
+	z := sg.Sub(sg.Add(c,c),sg.Scale(c,big.NewInt(2)))
+	if zero,e := key.IsZero(rng,z,auths); e!=nil || !zero { t.Errorf("IsZero(0) = %v, %v",zero,e) }
+	if zero,e := key.IsZero(rng,c,auths); e!=nil || zero { t.Errorf("IsZero(7) = %v, %v",zero,e) }
+	r,e := sg.Rerandomize(rng,key.Y,c)
+	if e!=nil || r.A.Equal(c.A) { t.Fatal("Rerandomize kept the ciphertext") }
+	if m,_ := key.Decrypt(rng,r,auths); !m.Equal(want) { t.Error("Rerandomize changed the plaintext") }
+}
+
+func TestBitProof(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	key,_,e := sg.GenerateThreshold(rng,1,1)
+	if e!=nil { t.Fatal(e) }
+	for bit := 0; bit<2; bit++ {
+		c,r,_ := sg.Encrypt(rng,key.Y,big.NewInt(int64(bit)))
+		p,e := sg.ProveBit(rng,key.Y,c,bit,r)
+		if e!=nil { t.Fatal(e) }
+		if !sg.VerifyBit(key.Y,c,p) { t.Fatalf("proof for bit %d rejected",bit) }
+		bad := *p
+		bad.S0 = new(big.Int).Add(p.S0,big.NewInt(1))
+		if sg.VerifyBit(key.Y,c,&bad) { t.Error("tampered proof accepted") }
+		if sg.VerifyBit(key.Y,sg.Add(c,sg.Trivial(big.NewInt(1))),p) { t.Error("proof accepted for another ciphertext") }
+	}
+	for _,v := range []int64{2,-1} {
+		c,r,_ := sg.Encrypt(rng,key.Y,big.NewInt(v))
+		for bit := 0; bit<2; bit++ {
+			p,_ := sg.ProveBit(rng,key.Y,c,bit,r)
+			if sg.VerifyBit(key.Y,c,p) { t.Errorf("proof accepted for plaintext %d",v) }
+		}
+	}
+	c,r,_ := sg.Encrypt(rng,key.Y,big.NewInt(1))
+	p,_ := sg.ProveBit(rng,key.Y,c,1,r)
+	outside,_ := sg.Deterministic(rng,4)
+	if sg.VerifyBit(key.Y,Ciphertext{c.A,sg.Multiply(c.B,outside)},p) { t.Error("ciphertext outside the subgroup accepted") }
+	if sg.VerifyBit(key.Y,c,nil) { t.Error("missing proof accepted") }
+}
+
+// A share D' = -D leaves the subgroup; its proof passes verifyDLEQ for about half of the challenges.
+func TestCombineOutsideSubgroup(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	key,auths,e := sg.GenerateThreshold(rng,3,2)
+	if e!=nil { t.Fatal(e) }
+	c,_,_ := sg.Encrypt(rng,key.Y,big.NewInt(3))
+	good,_ := auths[1].PartialDecrypt(rng,c)
+	forged := &PartialDecryption{Index:good.Index,D:sg.Neg(good.D)}
+	for i := 0; i<64 && forged.Proof==nil; i++ {
+		p,_ := sg.proveDLEQ(rng,"decrypt",sg.G,key.VK[good.Index-1],c.A,forged.D,auths[1].share)
+		if sg.verifyDLEQ("decrypt",sg.G,key.VK[good.Index-1],c.A,forged.D,p) { forged.Proof = p }
+	}
+	if forged.Proof==nil { t.Fatal("no passing proof found") }
+	first,_ := auths[0].PartialDecrypt(rng,c)
+	if _,e := key.Combine(c,[]*PartialDecryption{first,forged}); e==nil { t.Error("share outside the subgroup accepted") }
+	outside := Ciphertext{sg.Neg(c.A),c.B}
+	if _,e := key.Combine(outside,[]*PartialDecryption{first,good}); e==nil { t.Error("ciphertext outside the subgroup accepted") }
+	if _,e := key.IsZero(rng,outside,auths); e==nil { t.Error("IsZero accepted a ciphertext outside the subgroup") }
+}
+
+// A zero divisor h = 1+5i (mod 13) gives h^cof of order other than Q; it must be skipped.
+func TestSubgroupZeroDivisor(t *testing.T) {
+	m := Modulus{big.NewInt(13)}
+	sg,e := m.Subgroup(bytes.NewReader([]byte{0,4,1,0}),2,big.NewInt(3))
+	if e!=nil { t.Fatal(e) }
+	if !isOne(m.Exp(sg.G,[]byte{3})) || isOne(sg.G) { t.Fatalf("generator %v is not of order 3",sg.G) }
+}
@@ -0,0 +1,60 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math/big"
+import "errors"
+
+// Size of one encoded coefficient.
+func (m Modulus) coefLen() int { return (m.Mod.BitLen()+7)/8 }
+
+/*
+Encodes a as the concatenation of its coefficients, each reduced modulo P
+and written big-endian with a fixed width of ceil(bitlen(P)/8) bytes.
+*/
+func (m Modulus) Encode(a MultiComp) []byte {
+	w := m.coefLen()
+	buf := make([]byte,w*len(a))
+	for i,c := range a {
+		new(big.Int).Mod(c,m.Mod).FillBytes(buf[i*w:(i+1)*w])
+	}
+	return buf
+}
+
+/*
+Decodes an element encoded with Encode. The number of coefficients must be a
+power of two and every coefficient must be reduced.
+*/
+func (m Modulus) Decode(b []byte) (MultiComp,error) {
+	w := m.coefLen()
+	if len(b)==0 || len(b)%w!=0 { return nil,errors.New("Invalid length") }
+	n := len(b)/w
+	if (n&(n-1))!=0 { return nil,errors.New("Must be power of two") }
+	a := make(MultiComp,n)
+	for i := range a {
+		a[i] = new(big.Int).SetBytes(b[i*w:(i+1)*w])
+		if a[i].Cmp(m.Mod)>=0 { return nil,errors.New("Coefficient not reduced") }
+	}
+	return a,nil
+}
@@ -0,0 +1,415 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package hypercomplex
+
+import "math/big"
+import "bytes"
+import "fmt"
+import "sort"
+import "strconv"
+import "sync"
+import "errors"
+
+// A prime power P^E.
+type Factor struct{
+	P *big.Int
+	E int
+}
+
+// A product of prime powers, sorted by prime.
+type Factorization []Factor
+
+func (f Factorization) Product() *big.Int {
+	r := big.NewInt(1)
+	for _,p := range f {
+		r.Mul(r,new(big.Int).Exp(p.P,big.NewInt(int64(p.E)),nil))
+	}
+	return r
+}
+func (f Factorization) String() string {
+	sb := new(bytes.Buffer)
+	for i,p := range f {
+		if i>0 { sb.WriteString(" * ") }
+		fmt.Fprint(sb,p.P)
+		if p.E>1 { fmt.Fprintf(sb,"^%d",p.E) }
+	}
+	return sb.String()
+}
+
+// Merges a list of primes (with repetitions) into a Factorization.
+func collect(primes []*big.Int) Factorization {
+	sort.Slice(primes,func(i,j int) bool { return primes[i].Cmp(primes[j])<0 })
+	var f Factorization
+	for _,p := range primes {
+		if len(f)>0 && f[len(f)-1].P.Cmp(p)==0 {
+			f[len(f)-1].E++
+		} else {
+			f = append(f,Factor{P:p,E:1})
+		}
+	}
+	return f
+}
+
+// Primes up to (and including) n.
+func smallPrimes(n uint64) []uint64 {
+	sieve := make([]bool,n+1)
+	var ps []uint64
+	for i := uint64(2); i<=n; i++ {
+		if sieve[i] { continue }
+		ps = append(ps,i)
+		for j := i*i; j<=n; j+=i { sieve[j] = true }
+	}
+	return ps
+}
+
+/*
+Divides out all primes up to 'bound'. Returns the primes found and the
+remaining cofactor.
+*/
+func TrialDivision(n *big.Int, bound uint64) (Factorization,*big.Int) {
+	one := big.NewInt(1)
+	rest := new(big.Int).Abs(n)
+	var found []*big.Int
+	q,r := new(big.Int),new(big.Int)
+	for _,p := range smallPrimes(bound) {
+		bp := new(big.Int).SetUint64(p)
+		if rest.Cmp(new(big.Int).Mul(bp,bp))<0 {
+			// No factor below sqrt(rest) is left, so rest is 1 or a prime.
+			if rest.Cmp(one)>0 && rest.IsUint64() && rest.Uint64()<=bound {
+				found = append(found,new(big.Int).Set(rest))
+				rest.Set(one)
+			}
+			break
+		}
+		for {
+			q.QuoRem(rest,bp,r)
+			if r.Sign()!=0 { break }
+			rest.Set(q)
+			found = append(found,bp)
+		}
+	}
+	return collect(found),rest
+}
+
+/*
+Brent's variant of Pollard's rho method. Returns a non-trivial factor of n,
+or nil if none was found within the given number of iterations.
+*/
+func PollardRho(n *big.Int, iterations int) *big.Int {
+	one := big.NewInt(1)
+	if n.Cmp(big.NewInt(3))<=0 { return nil }
+	if n.Bit(0)==0 { return big.NewInt(2) }
+	for c := int64(1); c<8; c++ {
+		bc := big.NewInt(c)
+		f := func(x *big.Int) *big.Int {
+			x.Mul(x,x).Add(x,bc)
+			return x.Mod(x,n)
+		}
+		y := big.NewInt(2)
+		x,ys := new(big.Int),new(big.Int)
+		q := big.NewInt(1)
+		g := big.NewInt(1)
+		d := new(big.Int)
+		const m = 64
+		for r,it := 1,0; g.Cmp(one)==0 && it<iterations; r *= 2 {
+			x.Set(y)
+			for i := 0; i<r; i++ { f(y) }
+			for k := 0; k<r && g.Cmp(one)==0; k += m {
+				ys.Set(y)
+				for i := 0; i<m && i<r-k; i++ {
+					f(y)
+					d.Sub(x,y).Abs(d)
+					q.Mul(q,d).Mod(q,n)
+					it++
+				}
+				g.GCD(nil,nil,q,n)
+			}
+		}
+		if g.Cmp(n)==0 {
+			// Overshot: redo the last block one step at a time.
+			for {
+				f(ys)
+				g.GCD(nil,nil,d.Sub(x,ys).Abs(d),n)
+				if g.Cmp(one)!=0 { break }
+			}
+		}
+		if g.Cmp(one)!=0 && g.Cmp(n)!=0 { return g }
+		if g.Cmp(one)==0 { return nil } // out of iterations
+	}
+	return nil
+}
+
+/*
+Pollard's p-1 method (stage 1 only). Finds a factor q of n if q-1 has no
+prime power factor above 'bound'. Returns nil on failure.
+*/
+func PollardPM1(n *big.Int, bound uint64) *big.Int {
+	one := big.NewInt(1)
+	if n.Cmp(one)<=0 { return nil }
+	a := big.NewInt(2)
+	g,t := new(big.Int),new(big.Int)
+	primes := smallPrimes(bound)
+	for i := 0; i<len(primes); i += 32 {
+		batch := primes[i:]
+		if len(batch)>32 { batch = batch[:32] }
+		saved := new(big.Int).Set(a)
+		for _,p := range batch {
+			pk := p
+			for pk <= bound/p { pk *= p }
+			a.Exp(a,new(big.Int).SetUint64(pk),n)
+		}
+		g.GCD(nil,nil,t.Sub(a,one),n)
+		if g.Cmp(one)==0 { continue }
+		if g.Cmp(n)!=0 { return g }
+		// Every factor showed up at once. Replay the batch one prime at a time.
+		a.Set(saved)
+		for _,p := range batch {
+			bp := new(big.Int).SetUint64(p)
+			for pk := p; ; pk *= p {
+				a.Exp(a,bp,n)
+				g.GCD(nil,nil,t.Sub(a,one),n)
+				if g.Cmp(one)!=0 {
+					if g.Cmp(n)!=0 { return g }
+					return nil
+				}
+				if pk > bound/p { break }
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+/*
+Lenstra's elliptic curve method, stage 1 only, on Montgomery curves with
+Suyama's parametrization (sigma = 6, 7, ...). Tries the given number of
+curves with smoothness bound 'bound'. Returns nil on failure.
+*/
+func ECM(n *big.Int, bound uint64, curves int) *big.Int {
+	one := big.NewInt(1)
+	if n.Cmp(one)<=0 { return nil }
+	primes := smallPrimes(bound)
+	mod := func(x *big.Int) *big.Int { return x.Mod(x,n) }
+	for c := 0; c<curves; c++ {
+		sigma := big.NewInt(int64(6+c))
+		u := mod(new(big.Int).Sub(new(big.Int).Mul(sigma,sigma),big.NewInt(5)))
+		v := mod(new(big.Int).Lsh(sigma,2))
+		X := mod(new(big.Int).Exp(u,big.NewInt(3),n))
+		Z := mod(new(big.Int).Exp(v,big.NewInt(3),n))
+
+		// a24 = (A+2)/4 = (v-u)^3 (3u+v) / (16 u^3 v)
+		num := new(big.Int).Exp(new(big.Int).Sub(v,u),big.NewInt(3),n)
+		num = mod(num.Mul(num,new(big.Int).Add(new(big.Int).Mul(u,big.NewInt(3)),v)))
+		den := mod(new(big.Int).Mul(new(big.Int).Lsh(X,4),v))
+		g := new(big.Int).GCD(nil,nil,den,n)
+		if g.Cmp(n)==0 { continue }
+		if g.Cmp(one)!=0 { return g }
+		a24 := mod(num.Mul(num,new(big.Int).ModInverse(den,n)))
+
+		dbl := func(X,Z *big.Int) (*big.Int,*big.Int) {
+			t1 := new(big.Int).Add(X,Z); t1 = mod(t1.Mul(t1,t1))
+			t2 := new(big.Int).Sub(X,Z); t2 = mod(t2.Mul(t2,t2))
+			t3 := new(big.Int).Sub(t1,t2)
+			Z2 := mod(new(big.Int).Mul(a24,t3))
+			Z2 = mod(Z2.Mul(Z2.Add(Z2,t2),t3))
+			return mod(t1.Mul(t1,t2)),Z2
+		}
+		add := func(XP,ZP,XQ,ZQ,XD,ZD *big.Int) (*big.Int,*big.Int) {
+			s := new(big.Int).Mul(new(big.Int).Sub(XP,ZP),new(big.Int).Add(XQ,ZQ))
+			d := new(big.Int).Mul(new(big.Int).Add(XP,ZP),new(big.Int).Sub(XQ,ZQ))
+			x := new(big.Int).Add(s,d); x = mod(x.Mul(x,x))
+			z := new(big.Int).Sub(s,d); z = mod(z.Mul(z,z))
+			return mod(x.Mul(x,ZD)),mod(z.Mul(z,XD))
+		}
+		// Montgomery ladder for k*(X:Z).
+		mul := func(X,Z *big.Int, k uint64) (*big.Int,*big.Int) {
+			X0,Z0 := X,Z
+			X1,Z1 := dbl(X,Z)
+			hb := 63
+			for k>>uint(hb)==0 { hb-- }
+			for i := hb-1; i>=0; i-- {
+				if (k>>uint(i))&1==1 {
+					X0,Z0 = add(X1,Z1,X0,Z0,X,Z)
+					X1,Z1 = dbl(X1,Z1)
+				} else {
+					X1,Z1 = add(X0,Z0,X1,Z1,X,Z)
+					X0,Z0 = dbl(X0,Z0)
+				}
+			}
+			return X0,Z0
+		}
+		for _,p := range primes {
+			pk := p
+			for pk <= bound/p { pk *= p }
+			X,Z = mul(X,Z,pk)
+		}
+		g.GCD(nil,nil,Z,n)
+		if g.Cmp(one)!=0 && g.Cmp(n)!=0 { return g }
+	}
+	return nil
+}
+
+/*
+Splits composite n with the methods above, cheapest first. Returns nil if
+none succeeds.
+*/
+func split(n *big.Int) *big.Int {
+	if d := PollardPM1(n,100000); d!=nil { return d }
+	if d := PollardRho(n,1<<18); d!=nil { return d }
+	return ECM(n,2000,20)
+}
+
+/*
+Factors n as far as the methods in this file get: trial division,
+Pollard's p-1, Pollard's rho and ECM. Returns the prime factors found and
+the remaining composite cofactor, which is 1 if the factorization is complete.
+Primality of the factors is established with big.Int.ProbablyPrime.
+For n < 2 there is nothing to factor: the result is empty and the cofactor n.
+*/
+func Factorize(n *big.Int) (Factorization,*big.Int) {
+	return factorWith(n,nil)
+}
+
+/*
+Like Factorize, but splits n along the known factors first. The hints need
+not be prime; each must divide n.
+*/
+func factorWith(n *big.Int, hints []*big.Int) (Factorization,*big.Int) {
+	one := big.NewInt(1)
+	if n.Cmp(one)<=0 { return nil,new(big.Int).Set(n) }
+	small,rest := TrialDivision(n,1<<16)
+	var primes []*big.Int
+	for _,f := range small {
+		for i := 0; i<f.E; i++ { primes = append(primes,f.P) }
+	}
+	pending := []*big.Int{rest}
+	for _,h := range hints {
+		for again := true; again; {
+			again = false
+			var next []*big.Int
+			for _,x := range pending {
+				g := new(big.Int).GCD(nil,nil,x,h)
+				if g.Cmp(one)!=0 && g.Cmp(x)!=0 {
+					next = append(next,g,new(big.Int).Quo(x,g))
+					again = true
+				} else {
+					next = append(next,x)
+				}
+			}
+			pending = next
+		}
+	}
+	unfactored := big.NewInt(1)
+	for len(pending)>0 {
+		x := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if x.Cmp(one)==0 { continue }
+		if x.ProbablyPrime(20) {
+			primes = append(primes,x)
+			continue
+		}
+		d := split(x)
+		if d==nil {
+			unfactored.Mul(unfactored,x)
+			continue
+		}
+		pending = append(pending,d,new(big.Int).Quo(x,d))
+	}
+	return collect(primes),unfactored
+}
+
+type factorEntry struct{
+	f Factorization
+	rest *big.Int
+}
+
+// A deep copy, so that callers cannot modify the cache.
+func (e factorEntry) clone() (Factorization,*big.Int) {
+	f := make(Factorization,len(e.f))
+	for i,p := range e.f { f[i] = Factor{new(big.Int).Set(p.P),p.E} }
+	return f,new(big.Int).Set(e.rest)
+}
+
+/*
+Caches factorizations of P+delta (typically P-1 and P+1) per modulus.
+It is safe for concurrent use.
+*/
+type FactorCache struct{
+	mu sync.Mutex
+	m map[string]factorEntry
+}
+
+// A process-wide cache.
+var DefaultFactors = new(FactorCache)
+
+func factorKey(m Modulus, delta int64) string {
+	return m.Mod.Text(16)+"/"+strconv.FormatInt(delta,10)
+}
+
+func (c *FactorCache) get(k string) (factorEntry,bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e,ok := c.m[k]
+	return e,ok
+}
+func (c *FactorCache) put(k string, e factorEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.m==nil { c.m = make(map[string]factorEntry) }
+	c.m[k] = e
+}
+
+/*
+Factors P+delta, or returns the cached result. As with Factorize, the
+second result is the unfactored cofactor. Both are copies that the caller
+may modify.
+*/
+func (c *FactorCache) Factor(m Modulus, delta int64) (Factorization,*big.Int) {
+	n := new(big.Int).Add(m.Mod,big.NewInt(delta))
+	if n.Cmp(big.NewInt(1))<=0 { return nil,n }
+	k := factorKey(m,delta)
+	if e,ok := c.get(k); ok { return e.clone() }
+	e := factorEntry{}
+	e.f,e.rest = Factorize(n)
+	c.put(k,e)
+	return e.clone()
+}
+
+/*
+Supplies known factors of P+delta, so that the expensive methods only run on
+what is left. The factors need not be prime, but each must divide P+delta.
+Replaces any cached result.
+*/
+func (c *FactorCache) Supply(m Modulus, delta int64, known ...*big.Int) error {
+	n := new(big.Int).Add(m.Mod,big.NewInt(delta))
+	if n.Sign()<=0 { return errors.New("Nothing to factor") }
+	r := new(big.Int)
+	for _,k := range known {
+		if k.Sign()<=0 || r.Mod(n,k).Sign()!=0 { return fmt.Errorf("%v does not divide P%+d",k,delta) }
+	}
+	f,rest := factorWith(n,known)
+	c.put(factorKey(m,delta),factorEntry{f,rest})
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+
+// The least prime of the form k*base+1, k >= 1.
+func primeAbove(base *big.Int, k int64) *big.Int {
+	for ; ; k++ {
+		p := new(big.Int).Mul(base,big.NewInt(k))
+		if p.Add(p,big.NewInt(1)).ProbablyPrime(20) { return p }
+	}
+}
+
+// The least safe prime 2q+1 with q >= start.
+func safePrime(start int64) *big.Int {
+	for q := start; ; q++ {
+		bq := big.NewInt(q)
+		p := new(big.Int).Lsh(bq,1)
+		if bq.ProbablyPrime(20) && p.Add(p,big.NewInt(1)).ProbablyPrime(20) { return p }
+	}
+}
+
+func checkDivisor(t *testing.T, method string, n,d *big.Int) {
+	t.Helper()
+	if d==nil { t.Fatalf("%s found no factor of %v",method,n) }
+	if d.Cmp(big.NewInt(1))<=0 || d.Cmp(n)>=0 || new(big.Int).Mod(n,d).Sign()!=0 {
+		t.Fatalf("%s returned %v, not a proper factor of %v",method,d,n)
+	}
+}
+
+func TestTrialDivision(t *testing.T) {
+	for _,c := range []struct{
+		n int64
+		bound uint64
+		f string
+		rest int64
+	}{
+		{12,100,"2^2 * 3",1},
+		{4*97,100,"2^2 * 97",1},
+		{97*97,100,"97^2",1},
+		{6*1000003,100,"2 * 3",1000003},
+		{101*103,100,"",101*103},
+		{1,100,"",1},
+		{-30,100,"2 * 3 * 5",1},
+	} {
+		f,rest := TrialDivision(big.NewInt(c.n),c.bound)
+		if f.String()!=c.f || rest.Int64()!=c.rest {
+			t.Errorf("TrialDivision(%d,%d) = %v, %v; want %v, %d",c.n,c.bound,f,rest,c.f,c.rest)
+		}
+	}
+}
+
+func TestPollardRho(t *testing.T) {
+	n := big.NewInt(1000003*1000033)
+	checkDivisor(t,"PollardRho",n,PollardRho(n,1<<20))
+	if d := PollardRho(big.NewInt(1000003),1<<12); d!=nil { t.Errorf("PollardRho split a prime: %v",d) }
+}
+
+func TestPollardPM1(t *testing.T) {
+	smooth := big.NewInt(2*3*5*7*11*13*17*19*23)
+	p := primeAbove(smooth,1000)
+	q := safePrime(1<<40)
+	n := new(big.Int).Mul(p,q)
+	d := PollardPM1(n,2000)
+	checkDivisor(t,"PollardPM1",n,d)
+	if d.Cmp(p)!=0 { t.Errorf("PollardPM1 found %v, want %v",d,p) }
+	if d := PollardPM1(q,1000); d!=nil { t.Errorf("PollardPM1 split a prime: %v",d) }
+}
+
+func TestECM(t *testing.T) {
+	a := safePrime(1<<28)
+	b := safePrime(1<<56)
+	n := new(big.Int).Mul(a,b)
+	// p-1 fails on safe primes, ECM must not.
+	if d := PollardPM1(n,2000); d!=nil { t.Fatalf("PollardPM1 unexpectedly split %v",n) }
+	checkDivisor(t,"ECM",n,ECM(n,2000,40))
+}
+
+func TestFactorize(t *testing.T) {
+	n := new(big.Int).Mul(big.NewInt(12*1000003),safePrime(1<<40))
+	f,rest := Factorize(n)
+	if rest.Cmp(big.NewInt(1))!=0 || f.Product().Cmp(n)!=0 { t.Fatalf("Factorize(%v) = %v, %v",n,f,rest) }
+	for _,x := range []int64{1,0,-5} {
+		f,rest := Factorize(big.NewInt(x))
+		if len(f)!=0 || rest.Int64()!=x { t.Errorf("Factorize(%d) = %v, %v",x,f,rest) }
+		if PollardPM1(big.NewInt(x),100)!=nil || PollardRho(big.NewInt(x),100)!=nil || ECM(big.NewInt(x),100,1)!=nil {
+			t.Errorf("a method split %d",x)
+		}
+	}
+}
+
+func TestFactorCache(t *testing.T) {
+	p := safePrime(1<<40)
+	m := Modulus{p}
+	c := new(FactorCache)
+	f,rest := c.Factor(m,-1)
+	if rest.Cmp(big.NewInt(1))!=0 || len(f)!=2 || f[0].P.Int64()!=2 { t.Fatalf("P-1 = %v, rest %v",f,rest) }
+	// Results are copies: modifying them leaves the cache intact.
+	f[0].P.SetInt64(5)
+	rest.SetInt64(7)
+	if g,r := c.Factor(m,-1); g[0].P.Int64()!=2 || r.Int64()!=1 { t.Fatalf("cache modified through a result: %v, %v",g,r) }
+	// A cached entry is returned as it is, without factoring again.
+	fake := factorEntry{Factorization{{big.NewInt(2),1}},new(big.Int).Rsh(p,1)}
+	c.put(factorKey(m,-1),fake)
+	if g,r := c.Factor(m,-1); len(g)!=1 || r.Cmp(fake.rest)!=0 { t.Errorf("cached entry not used: %v, %v",g,r) }
+	if f,rest := c.Factor(m,new(big.Int).Neg(p).Int64()); len(f)!=0 || rest.Sign()!=0 { t.Errorf("P-P = %v, %v",f,rest) }
+	q := new(big.Int).Rsh(p,1)
+	if e := c.Supply(m,-1,q); e!=nil { t.Fatal(e) }
+	if e := c.Supply(m,-1,big.NewInt(3)); e==nil { t.Error("Supply accepted a non-divisor") }
+	if e := c.Supply(m,new(big.Int).Neg(p).Int64(),big.NewInt(1)); e==nil { t.Error("Supply accepted P-P") }
+}
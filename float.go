@@ -0,0 +1,147 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math"
+import "bytes"
+import "fmt"
+
+/*
+Represents a Hyper-Complex number with float64 coefficients, laid out like
+MultiComp: a power-of-two number of coefficients, where the first half is the
+real part and the second half the imaginary part. A MultiFloat of length 4 is
+a bicomplex number, one of length 8 a tricomplex number.
+*/
+type MultiFloat []float64
+
+func (m MultiFloat) String() string {
+	sb := bytes.NewBuffer([]byte{'['})
+	for i,b := range m {
+		if i>0 { sb.WriteByte(',') }
+		fmt.Fprintf(sb,"%g",b)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+func (m MultiFloat) Copy() MultiFloat {
+	return append(MultiFloat(nil),m...)
+}
+
+// Euclidean norm of the coefficient vector.
+func (m MultiFloat) Norm() float64 {
+	s := 0.0
+	for _,c := range m { s += c*c }
+	return math.Sqrt(s)
+}
+
+func (a MultiFloat) Add(b MultiFloat) MultiFloat {
+	c := make(MultiFloat,len(a))
+	for i := range c { c[i] = a[i]+b[i] }
+	return c
+}
+func (a MultiFloat) Sub(b MultiFloat) MultiFloat {
+	c := make(MultiFloat,len(a))
+	for i := range c { c[i] = a[i]-b[i] }
+	return c
+}
+func (a MultiFloat) Scale(k float64) MultiFloat {
+	c := make(MultiFloat,len(a))
+	for i := range c { c[i] = a[i]*k }
+	return c
+}
+func (a MultiFloat) Multiply(b MultiFloat) MultiFloat {
+	// assert: len(a)==len(b)
+	L := len(a)/2
+	if L==0 { return MultiFloat{a[0]*b[0]} }
+	ar,ai := a[:L],a[L:]
+	br,bi := b[:L],b[L:]
+	cr := ar.Multiply(br).Sub(ai.Multiply(bi))
+	ci := ar.Multiply(bi).Add(ai.Multiply(br))
+	return append(cr,ci...)
+}
+
+// For a given 'a = (r,i)' it returns '(r,-i)'.
+func (a MultiFloat) Counterpart() MultiFloat {
+	L := len(a)/2
+	if L==0 { return a }
+	return append(a[:L].Copy(),a[L:].Scale(-1)...)
+}
+
+/*
+Computes the inverse of a, the same way as Modulus.Inverse does. Zero
+divisors yield infinite or NaN coefficients.
+*/
+func (a MultiFloat) Inverse() MultiFloat {
+	L := len(a)/2
+	if L==0 { return MultiFloat{1/a[0]} }
+	cp := a.Counterpart()
+	prod := a.Multiply(cp)
+	// imaginary(a * counterpart(a)) = 0, up to rounding.
+	prod = append(prod[:L].Inverse(),make(MultiFloat,L)...)
+	return prod.Multiply(cp)
+}
+
+func (a MultiFloat) finite() bool {
+	for _,c := range a {
+		if math.IsInf(c,0) || math.IsNaN(c) { return false }
+	}
+	return true
+}
+
+/*
+Returns the idempotent components of a (len(a) >= 2) as complex numbers.
+The lowest unit i1 plays the part of the complex i, and every other unit j
+is split with the idempotents (1 -+ i1*j)/2, as in the modular case. The
+components multiply pointwise, and a is invertible iff no component is zero.
+A real number (len(a) < 2) has no complex components; the result is nil.
+*/
+func (a MultiFloat) Components() []complex128 {
+	if len(a)<2 { return nil }
+	if len(a)==2 { return []complex128{complex(a[0],a[1])} }
+	L := len(a)/2
+	u := a[:L].Components()
+	v := a[L:].Components()
+	c := make([]complex128,2*len(u))
+	for k := range u {
+		c[k] = u[k]+1i*v[k]
+		c[len(u)+k] = u[k]-1i*v[k]
+	}
+	return c
+}
+
+// Inverse of Components. Returns nil for an empty slice.
+func FromComponents(c []complex128) MultiFloat {
+	if len(c)==0 { return nil }
+	if len(c)==1 { return MultiFloat{real(c[0]),imag(c[0])} }
+	L := len(c)/2
+	u := make([]complex128,L)
+	v := make([]complex128,L)
+	for k := range u {
+		A,B := c[k],c[L+k]
+		u[k] = (A+B)/2
+		v[k] = (A-B)/2i
+	}
+	return append(FromComponents(u),FromComponents(v)...)
+}
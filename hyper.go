@@ -63,6 +63,15 @@ func (m MultiComp) Copy() MultiComp {
 	return n
 }
 
+// Reports whether m and n have the same length and coefficients.
+func (m MultiComp) Equal(n MultiComp) bool {
+	if len(m)!=len(n) { return false }
+	for i := range m {
+		if m[i].Cmp(n[i])!=0 { return false }
+	}
+	return true
+}
+
 type Modulus struct{
 	Mod *big.Int
 }
@@ -177,18 +186,21 @@ func (m Modulus) Counterpart(a MultiComp) MultiComp{
 	return append(ar.Copy(),m.Neg(ai)...)
 }
 
-// Computes the modulo inverse of a.
+// Computes the modulo inverse of a. Returns nil if a is not a unit.
 func (m Modulus) Inverse(a MultiComp) MultiComp {
 	// assert: len(a)==len(b)
 	L := len(a)/2
 	if L==0 {
 		r := new(big.Int).ModInverse(a[0],m.Mod)
+		if r==nil { return nil }
 		return MultiComp{r}
 	}
 	ar := a[:L]
 	ai := a[L:]
 	if isZero(ai) {
-		return append(m.Inverse(ar),ai...)
+		inv := m.Inverse(ar)
+		if inv==nil { return nil }
+		return append(inv,ai...)
 	}
 	/*
 	Lemma: imaginary(a * counterpart(a)) = 0
@@ -198,7 +210,9 @@ func (m Modulus) Inverse(a MultiComp) MultiComp {
 	*/
 	cp := m.Counterpart(a)
 	prod := m.Multiply(a,cp)
-	prod = append(m.Inverse(prod[:L]),prod[L:]...)
+	inv := m.Inverse(prod[:L])
+	if inv==nil { return nil } // a*counterpart(a) is a unit iff a is
+	prod = append(inv,prod[L:]...)
 	
 	prod = m.Multiply(prod,cp)
 	return prod
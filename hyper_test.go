@@ -0,0 +1,71 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+
+func mc(xs ...int64) MultiComp {
+	r := make(MultiComp,len(xs))
+	for i,x := range xs { r[i] = big.NewInt(x) }
+	return r
+}
+
+func TestInverse(t *testing.T) {
+	for _,m := range append(tuneModuli,Modulus{big.NewInt(13)}) {
+		for _,n := range []int{1,2,4,8} {
+			for i := 0; i<8; i++ {
+				a := randMC(m,n)
+				inv := m.Inverse(a)
+				if inv==nil { continue }
+				if p := m.Multiply(a,inv); !isOne(p) { t.Fatalf("P=%v: %v*%v = %v",m.Mod,a,inv,p) }
+			}
+		}
+	}
+}
+
+func TestInverseNonUnit(t *testing.T) {
+	p13 := Modulus{big.NewInt(13)}
+	p := tuneModuli[1] // P = 3 (mod 4), so dimension 2 is a field
+	for _,c := range []struct{
+		m Modulus
+		a MultiComp
+	}{
+		{p13,mc(0)},
+		{p13,mc(0,0)},
+		{p13,mc(1,5)}, // 1+25 = 0 (mod 13)
+		{p13,mc(1,5,0,0)},
+		{p13,mc(3,2,0,0)},
+		{p,mc(1,0,0,1)}, // (1+ij)(1-ij) = 0
+		{p,mc(0,0,0,0,0,0,0,0)},
+		{tuneModuli[2],mc(3,0)},
+		{tuneModuli[2],mc(1,0,0,1,0,0,0,0)},
+	} {
+		if inv := c.m.Inverse(c.a); inv!=nil { t.Errorf("P=%v: inverse of %v is %v",c.m.Mod,c.a,inv) }
+	}
+	f,e := p.Fixed()
+	if e!=nil { t.Fatal(e) }
+	if f.Inverse(mc(1,0,0,1))!=nil { t.Error("Fixed inverted a zero divisor") }
+	if p.tuned().Inverse(mc(1,0,0,1))!=nil { t.Error("Tuned inverted a zero divisor") }
+}
@@ -0,0 +1,240 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math/big"
+import "bytes"
+import "fmt"
+import "errors"
+
+/*
+An ideal of the multicomplex ring of a given dimension over a prime P.
+
+Under the idempotent decomposition (see splitting) the ring is a product of
+fields: copies of F_P if P = 1 (mod 4), copies of F_P[i1] = F_(P^2)
+otherwise. Every ideal is therefore the set of elements that vanish outside
+some set of components, its support, and is generated by a single idempotent.
+All operations below reduce to set operations on the support.
+*/
+type Ideal struct{
+	d *splitting
+	dim int
+	support []bool
+}
+
+func (m Modulus) components(dim int) (*splitting,error) {
+	if dim<1 || (dim&(dim-1))!=0 { return nil,errors.New("Must be power of two") }
+	if !m.Mod.ProbablyPrime(20) { return nil,errors.New("Modulus must be prime") }
+	return m.splitting()
+}
+
+// The components of a in which it is nonzero.
+func (d *splitting) support(a MultiComp) []bool {
+	w := d.width(len(a))
+	c := d.split(a)
+	s := make([]bool,len(a)/w)
+	for k := range s {
+		s[k] = !isZero(c[k*w:(k+1)*w])
+	}
+	return s
+}
+
+// The ideal generated by gens, all of length dim. No generators: the zero ideal.
+func (m Modulus) Ideal(dim int, gens ...MultiComp) (*Ideal,error) {
+	d,e := m.components(dim)
+	if e!=nil { return nil,e }
+	I := &Ideal{d,dim,make([]bool,dim/d.width(dim))}
+	for _,g := range gens {
+		if len(g)!=dim { return nil,errors.New("Generator has the wrong dimension") }
+		for k,nz := range d.support(g) {
+			if nz { I.support[k] = true }
+		}
+	}
+	return I,nil
+}
+
+// The annihilator of a: all x with a*x = 0.
+func (m Modulus) Annihilator(a MultiComp) (*Ideal,error) {
+	d,e := m.components(len(a))
+	if e!=nil { return nil,e }
+	s := d.support(a)
+	for k := range s { s[k] = !s[k] }
+	return &Ideal{d,len(a),s},nil
+}
+
+func (I *Ideal) compatible(J *Ideal) error {
+	if I.dim!=J.dim || I.d.m.Mod.Cmp(J.d.m.Mod)!=0 { return errors.New("Ideals of different rings") }
+	return nil
+}
+
+func (I *Ideal) combine(J *Ideal, f func(a,b bool) bool) (*Ideal,error) {
+	if e := I.compatible(J); e!=nil { return nil,e }
+	K := &Ideal{I.d,I.dim,make([]bool,len(I.support))}
+	for k := range K.support { K.support[k] = f(I.support[k],J.support[k]) }
+	return K,nil
+}
+
+func (I *Ideal) Contains(a MultiComp) bool {
+	if len(a)!=I.dim { return false }
+	for k,nz := range I.d.support(a) {
+		if nz && !I.support[k] { return false }
+	}
+	return true
+}
+
+// I ∩ J. As the components are fields, this is also the product I*J.
+func (I *Ideal) Intersect(J *Ideal) (*Ideal,error) {
+	return I.combine(J,func(a,b bool) bool { return a && b })
+}
+
+// I + J.
+func (I *Ideal) Sum(J *Ideal) (*Ideal,error) {
+	return I.combine(J,func(a,b bool) bool { return a || b })
+}
+
+// The ideal quotient (I : J), all x with x*J contained in I.
+func (I *Ideal) Quotient(J *Ideal) (*Ideal,error) {
+	return I.combine(J,func(a,b bool) bool { return a || !b })
+}
+
+func (I *Ideal) Equal(J *Ideal) bool {
+	if I.compatible(J)!=nil { return false }
+	for k := range I.support {
+		if I.support[k]!=J.support[k] { return false }
+	}
+	return true
+}
+
+// Reports whether I is the whole ring, ie. contains a unit.
+func (I *Ideal) IsUnit() bool {
+	for _,s := range I.support {
+		if !s { return false }
+	}
+	return true
+}
+
+// Reports whether I is the zero ideal.
+func (I *Ideal) IsZero() bool {
+	for _,s := range I.support {
+		if s { return false }
+	}
+	return true
+}
+
+// The components in the support of I, numbered in the order of split.
+func (I *Ideal) Support() []int {
+	var r []int
+	for k,s := range I.support {
+		if s { r = append(r,k) }
+	}
+	return r
+}
+
+// Dimension of I as a vector space over F_P.
+func (I *Ideal) Rank() int {
+	return len(I.Support())*I.d.width(I.dim)
+}
+
+// The idempotent e with I = (e): one on the support and zero elsewhere.
+func (I *Ideal) Generator() MultiComp {
+	w := I.d.width(I.dim)
+	c := zeroes(I.dim)
+	for _,k := range I.Support() { c[k*w].SetUint64(1) }
+	return I.d.join(c)
+}
+
+/*
+A basis of I as an F_P-vector space in reduced row echelon form, so that
+equal ideals have equal bases.
+*/
+func (I *Ideal) Basis() []MultiComp {
+	w := I.d.width(I.dim)
+	var rows []MultiComp
+	for _,k := range I.Support() {
+		for i := 0; i<w; i++ {
+			c := zeroes(I.dim)
+			c[k*w+i].SetUint64(1)
+			rows = append(rows,I.d.join(c))
+		}
+	}
+	return rref(rows,I.d.m.Mod)
+}
+
+func rref(rows []MultiComp, p *big.Int) []MultiComp {
+	r := 0
+	t := new(big.Int)
+	for col := 0; len(rows)>0 && col<len(rows[0]) && r<len(rows); col++ {
+		piv := -1
+		for i := r; i<len(rows); i++ {
+			if rows[i][col].Sign()!=0 { piv = i; break }
+		}
+		if piv<0 { continue }
+		rows[r],rows[piv] = rows[piv],rows[r]
+		inv := new(big.Int).ModInverse(rows[r][col],p)
+		row := make(MultiComp,len(rows[r]))
+		for j,c := range rows[r] {
+			row[j] = new(big.Int).Mul(c,inv)
+			row[j].Mod(row[j],p)
+		}
+		rows[r] = row
+		for i := range rows {
+			if i==r || rows[i][col].Sign()==0 { continue }
+			f := new(big.Int).Set(rows[i][col])
+			row := make(MultiComp,len(rows[i]))
+			for j := range row {
+				row[j] = new(big.Int).Sub(rows[i][j],t.Mul(f,rows[r][j]))
+				row[j].Mod(row[j],p)
+			}
+			rows[i] = row
+		}
+		r++
+	}
+	return rows[:r]
+}
+
+func (I *Ideal) String() string {
+	if I.IsZero() { return "(0)" }
+	if I.IsUnit() { return "(1)" }
+	sb := new(bytes.Buffer)
+	fmt.Fprintf(sb,"ideal of rank %d spanned by",I.Rank())
+	for i,b := range I.Basis() {
+		if i>0 { sb.WriteByte(',') }
+		sb.WriteByte(' ')
+		sb.WriteString(b.String())
+	}
+	return sb.String()
+}
+
+/*
+Reports whether a is invertible, ie. whether Inverse returns non-nil.
+By the lemma used in Inverse, a is a unit exactly if a*counterpart(a), which
+has half the dimension, is one. Unlike Ideal, this works for any modulus.
+*/
+func (m Modulus) IsUnit(a MultiComp) bool {
+	L := len(a)/2
+	if L==0 {
+		return new(big.Int).GCD(nil,nil,a[0],m.Mod).Cmp(big.NewInt(1))==0
+	}
+	return m.IsUnit(m.Multiply(a,m.Counterpart(a))[:L])
+}
@@ -0,0 +1,141 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+import "math/rand"
+
+// P = 1 (mod 4), where components are F_P, and P = 3 (mod 4), where they are F_(P^2).
+var idealModuli = []Modulus{{big.NewInt(13)},{big.NewInt(11)},{big.NewInt(1000033)},{big.NewInt(1000003)}}
+
+// A random element whose support is exactly sup.
+func supported(rng *rand.Rand, d *splitting, dim int, sup []bool) MultiComp {
+	w := d.width(dim)
+	c := zeroes(dim)
+	for k,s := range sup {
+		if !s { continue }
+		for c[k*w].Sign()==0 { c[k*w].Rand(rng,d.m.Mod) }
+		if w==2 { c[k*w+1].Rand(rng,d.m.Mod) }
+	}
+	return d.join(c)
+}
+
+func randSupport(rng *rand.Rand, n int) []bool {
+	s := make([]bool,n)
+	for k := range s { s[k] = rng.Intn(2)==0 }
+	return s
+}
+
+func sameSupport(I *Ideal, want []bool) bool {
+	for k := range want {
+		if I.support[k]!=want[k] { return false }
+	}
+	return true
+}
+
+func TestIdealOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _,m := range idealModuli {
+		d,e := m.splitting()
+		if e!=nil { t.Fatal(e) }
+		for _,dim := range []int{1,2,4,8} {
+			n := dim/d.width(dim)
+			for i := 0; i<16; i++ {
+				sa,sb := randSupport(rng,n),randSupport(rng,n)
+				a,b := supported(rng,d,dim,sa),supported(rng,d,dim,sb)
+				I,e := m.Ideal(dim,a)
+				if e!=nil { t.Fatal(e) }
+				J,_ := m.Ideal(dim,b)
+				if !sameSupport(I,sa) { t.Fatalf("P=%v, dim %d: support of %v is %v, want %v",m.Mod,dim,a,I.support,sa) }
+				S,_ := I.Sum(J)
+				X,_ := I.Intersect(J)
+				Q,_ := I.Quotient(J)
+				A,_ := m.Annihilator(a)
+				Z,_ := m.Ideal(dim)
+				union,inter,quot,ann := make([]bool,n),make([]bool,n),make([]bool,n),make([]bool,n)
+				for k := range union {
+					union[k],inter[k],quot[k],ann[k] = sa[k]||sb[k],sa[k]&&sb[k],sa[k]||!sb[k],!sa[k]
+				}
+				if !sameSupport(S,union) || !sameSupport(X,inter) || !sameSupport(Q,quot) || !sameSupport(A,ann) {
+					t.Fatalf("P=%v, dim %d: wrong support for %v and %v",m.Mod,dim,sa,sb)
+				}
+				// Membership.
+				if !I.Contains(a) || !S.Contains(m.Add(a,b)) || !X.Contains(m.Multiply(a,b)) { t.Fatal("generated elements missing") }
+				if !I.Contains(m.Multiply(a,supported(rng,d,dim,randSupport(rng,n)))) { t.Fatal("ideal not closed under multiplication") }
+				for _,x := range A.Basis() {
+					if !isZero(m.Multiply(a,x)) { t.Fatalf("%v does not annihilate %v",x,a) }
+				}
+				// (I : J)*J is contained in I, and (0 : I) is the annihilator.
+				if x := m.Multiply(Q.Generator(),b); !I.Contains(x) { t.Fatal("(I:J)*J not in I") }
+				if ZI,_ := Z.Quotient(I); !ZI.Equal(A) { t.Fatal("(0:I) != Ann(a)") }
+				if II,_ := I.Quotient(I); !II.IsUnit() { t.Fatal("(I:I) != (1)") }
+				if IA,_ := I.Sum(A); !IA.IsUnit() { t.Fatal("I+Ann(I) != (1)") }
+				// The generator is an idempotent generating I, the basis has Rank elements.
+				g := I.Generator()
+				if !m.Multiply(g,g).Equal(g) { t.Fatal("generator not idempotent") }
+				if G,_ := m.Ideal(dim,g); !G.Equal(I) { t.Fatal("generator does not generate I") }
+				if bs := I.Basis(); len(bs)!=I.Rank() { t.Fatalf("basis of %d elements, rank %d",len(bs),I.Rank()) }
+			}
+		}
+	}
+	if _,e := (Modulus{big.NewInt(15)}).Ideal(2); e==nil { t.Error("composite modulus accepted") }
+	if _,e := idealModuli[0].Ideal(3); e==nil { t.Error("dimension 3 accepted") }
+}
+
+func TestIsUnit(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for _,m := range idealModuli {
+		d,_ := m.splitting()
+		for _,dim := range []int{1,2,4,8} {
+			n := dim/d.width(dim)
+			for i := 0; i<32; i++ {
+				a := supported(rng,d,dim,randSupport(rng,n))
+				if i%4==0 { a = randMC(m,dim) }
+				I,_ := m.Ideal(dim,a)
+				if m.IsUnit(a)!=I.IsUnit() { t.Fatalf("P=%v: IsUnit(%v) = %v, but the ideal is %v",m.Mod,a,m.IsUnit(a),I) }
+				if (m.Inverse(a)!=nil)!=I.IsUnit() { t.Fatalf("P=%v: Inverse(%v) disagrees with IsUnit",m.Mod,a) }
+			}
+		}
+	}
+}
+
+// Coefficients of P or more must be treated as their residues.
+func TestIdealUnreduced(t *testing.T) {
+	for _,m := range idealModuli {
+		P := m.Mod
+		P1 := new(big.Int).Add(P,big.NewInt(1))
+		for _,dim := range []int{1,2,4} {
+			z := zeroes(dim)
+			z[0].Set(P)
+			I,_ := m.Ideal(dim,z)
+			if !I.IsZero() || m.IsUnit(z) { t.Errorf("P=%v, dim %d: %v generates %v",P,dim,z,I) }
+			Z,_ := m.Ideal(dim)
+			if !Z.Contains(z) { t.Errorf("P=%v, dim %d: zero ideal does not contain %v",P,dim,z) }
+			u := zeroes(dim)
+			u[0].Set(P1)
+			if U,_ := m.Ideal(dim,u); !U.IsUnit() || !m.IsUnit(u) { t.Errorf("P=%v, dim %d: %v is not a unit",P,dim,u) }
+		}
+	}
+}
@@ -0,0 +1,238 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math/big"
+import "io"
+import "errors"
+
+/*
+The truncated power series ("jets") F_P[x]/(x^n). An element uses the
+MultiComp layout, a[i] being the coefficient of x^i, but any length n >= 1
+is allowed.
+
+Evaluating a polynomial at Variable(x0,n) yields its Taylor expansion at x0
+to order n-1, from which Derivatives reads off the first n-1 derivatives.
+Integral, Exponential and Logarithm divide by 1..n-1 and therefore need
+P >= n; P should be prime.
+
+Jet implements Algebra, but a Group over it is insecure: Logarithm reduces
+discrete logarithms in 1 + x*F_P[x] to linear algebra, leaving only the
+F_P^* part of a unit hard.
+*/
+type Jet struct{
+	Mod *big.Int
+}
+
+var ErrNotNilpotent = errors.New("Constant term must be zero")
+
+func (j Jet) base() Modulus { return Modulus{j.Mod} }
+
+func (j Jet) Add(a,b MultiComp) MultiComp { return j.base().Add(a,b) }
+func (j Jet) Sub(a,b MultiComp) MultiComp { return j.base().Sub(a,b) }
+func (j Jet) Neg(a MultiComp) MultiComp { return j.base().Neg(a) }
+
+// Fixed-width encoding of the coefficients, as Modulus.Encode.
+func (j Jet) Encode(a MultiComp) []byte { return j.base().Encode(a) }
+
+func (j Jet) Decode(b []byte) (MultiComp,error) {
+	m := j.base()
+	w := m.coefLen()
+	if len(b)==0 || len(b)%w!=0 { return nil,errors.New("Invalid length") }
+	a := make(MultiComp,len(b)/w)
+	for i := range a {
+		a[i] = new(big.Int).SetBytes(b[i*w:(i+1)*w])
+		if a[i].Cmp(j.Mod)>=0 { return nil,errors.New("Coefficient not reduced") }
+	}
+	return a,nil
+}
+
+/*
+Creates a jet of length 'size' with coefficients in 1..P-1 read from the
+source, as Modulus.Deterministic does. The result is always a unit.
+*/
+func (j Jet) Deterministic(source io.Reader, size int) (MultiComp,error) {
+	if size<1 { return nil,errors.New("Size must be positive") }
+	one := big.NewInt(1)
+	re := new(big.Int).Sub(j.Mod,one)
+	buf := make([]byte,len(re.Bytes()))
+	r := make(MultiComp,size)
+	for i := range r {
+		if _,e := io.ReadFull(source,buf); e!=nil { return nil,e }
+		r[i] = new(big.Int).SetBytes(buf)
+		r[i].Mod(r[i],re).Add(r[i],one)
+	}
+	return r,nil
+}
+
+/*
+Multiplies by Kronecker substitution: both operands are packed into one
+integer each, with slots wide enough for a full convolution sum, so the
+product is a single big.Int multiplication.
+*/
+func (j Jet) Multiply(a,b MultiComp) MultiComp {
+	n := len(a)
+	k := 0
+	for 1<<uint(k)<n { k++ }
+	wb := (2*j.Mod.BitLen()+k+8)/8
+	pack := func(x MultiComp) *big.Int {
+		buf := make([]byte,n*wb)
+		for i,c := range x {
+			o := len(buf)-(i+1)*wb
+			new(big.Int).Mod(c,j.Mod).FillBytes(buf[o:o+wb])
+		}
+		return new(big.Int).SetBytes(buf)
+	}
+	A := pack(a)
+	var C *big.Int
+	if &a[0]==&b[0] {
+		C = new(big.Int).Mul(A,A)
+	} else {
+		C = new(big.Int).Mul(A,pack(b))
+	}
+	buf := make([]byte,2*n*wb)
+	C.FillBytes(buf)
+	c := make(MultiComp,n)
+	for i := range c {
+		o := len(buf)-(i+1)*wb
+		c[i] = new(big.Int).SetBytes(buf[o:o+wb])
+		c[i].Mod(c[i],j.Mod)
+	}
+	return c
+}
+
+func (j Jet) Exp(g MultiComp, exp []byte) MultiComp {
+	v := zeroes(len(g))
+	v[0].SetUint64(1)
+	for _,k := range exp {
+		for i := 0; i<8; i++ {
+			v = j.Multiply(v,v)
+			if (k&0x80)==0x80 { v = j.Multiply(v,g) }
+			k <<= 1
+		}
+	}
+	return v
+}
+
+/*
+Computes 1/a by Newton iteration, b <- b*(2 - a*b), which doubles the number
+of correct coefficients per step. Returns nil if a[0] is not invertible.
+*/
+func (j Jet) Inverse(a MultiComp) MultiComp {
+	n := len(a)
+	b0 := new(big.Int).ModInverse(a[0],j.Mod)
+	if b0==nil { return nil }
+	b := MultiComp{b0}
+	two := big.NewInt(2)
+	for l := 1; l<n; {
+		l *= 2
+		if l>n { l = n }
+		b = append(b,zeroes(l-len(b))...)
+		t := j.Multiply(a[:l],b)
+		t = j.Neg(t)
+		t[0].Add(t[0],two).Mod(t[0],j.Mod)
+		b = j.Multiply(b,t)
+	}
+	return b
+}
+
+// The formal derivative. The top coefficient, which is unknown, is zero.
+func (j Jet) Derivative(a MultiComp) MultiComp {
+	d := zeroes(len(a))
+	for i := 1; i<len(a); i++ {
+		d[i-1].Mul(a[i],big.NewInt(int64(i))).Mod(d[i-1],j.Mod)
+	}
+	return d
+}
+
+// The antiderivative with constant term zero, truncated to the length of a.
+func (j Jet) Integral(a MultiComp) (MultiComp,error) {
+	r := zeroes(len(a))
+	for i := 1; i<len(a); i++ {
+		inv := new(big.Int).ModInverse(big.NewInt(int64(i)),j.Mod)
+		if inv==nil { return nil,errors.New("Modulus is too small for the length") }
+		r[i].Mul(a[i-1],inv).Mod(r[i],j.Mod)
+	}
+	return r,nil
+}
+
+/*
+Computes exp(a) for a with a[0] = 0, from exp(a)' = a' * exp(a):
+
+	b[k] = 1/k * sum(i*a[i]*b[k-i], i = 1..k)
+*/
+func (j Jet) Exponential(a MultiComp) (MultiComp,error) {
+	if a[0].Sign()!=0 { return nil,ErrNotNilpotent }
+	b := zeroes(len(a))
+	b[0].SetUint64(1)
+	t := new(big.Int)
+	for k := 1; k<len(a); k++ {
+		inv := new(big.Int).ModInverse(big.NewInt(int64(k)),j.Mod)
+		if inv==nil { return nil,errors.New("Modulus is too small for the length") }
+		for i := 1; i<=k; i++ {
+			t.Mul(a[i],b[k-i])
+			t.Mul(t,big.NewInt(int64(i)))
+			b[k].Add(b[k],t)
+		}
+		b[k].Mul(b[k],inv).Mod(b[k],j.Mod)
+	}
+	return b,nil
+}
+
+// Computes log(a) = integral(a'/a) for a with a[0] = 1.
+func (j Jet) Logarithm(a MultiComp) (MultiComp,error) {
+	if new(big.Int).Mod(a[0],j.Mod).Cmp(big.NewInt(1))!=0 { return nil,errors.New("Constant term must be one") }
+	return j.Integral(j.Multiply(j.Derivative(a),j.Inverse(a)))
+}
+
+// Computes f(g(x)) by Horner's rule; g must have a zero constant term.
+func (j Jet) Compose(f,g MultiComp) (MultiComp,error) {
+	if g[0].Sign()!=0 { return nil,ErrNotNilpotent }
+	r := zeroes(len(g))
+	for i := len(f)-1; i>=0; i-- {
+		r = j.Multiply(r,g)
+		r[0].Add(r[0],f[i]).Mod(r[0],j.Mod)
+	}
+	return r,nil
+}
+
+// The jet x0 + x of length n: the independent variable at x0.
+func (j Jet) Variable(x0 *big.Int, n int) MultiComp {
+	v := zeroes(n)
+	v[0].Mod(x0,j.Mod)
+	if n>1 { v[1].SetUint64(1) }
+	return v
+}
+
+// Returns f(x0), f'(x0), f''(x0), ..., the k-th entry being k! * a[k].
+func (j Jet) Derivatives(a MultiComp) []*big.Int {
+	d := make([]*big.Int,len(a))
+	f := big.NewInt(1)
+	for k := range a {
+		if k>0 { f.Mul(f,big.NewInt(int64(k))).Mod(f,j.Mod) }
+		d[k] = new(big.Int).Mul(a[k],f)
+		d[k].Mod(d[k],j.Mod)
+	}
+	return d
+}
@@ -0,0 +1,144 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+import "math/rand"
+
+// The truncated product by the definition.
+func naiveJetMul(j Jet, a,b MultiComp) MultiComp {
+	c := zeroes(len(a))
+	for i := range a {
+		for k := 0; i+k<len(a); k++ {
+			c[i+k].Add(c[i+k],new(big.Int).Mul(a[i],b[k]))
+			c[i+k].Mod(c[i+k],j.Mod)
+		}
+	}
+	return c
+}
+
+var testJet = Jet{hexModulus("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f").Mod}
+
+func jetOne(n int) MultiComp {
+	r := zeroes(n)
+	r[0].SetUint64(1)
+	return r
+}
+
+// A random jet with constant term zero.
+func nilpotent(rng *rand.Rand, j Jet, n int) MultiComp {
+	a,_ := j.Deterministic(rng,n)
+	a[0].SetUint64(0)
+	return a
+}
+
+func TestJetArithmetic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	j := testJet
+	for _,n := range []int{1,2,3,5,8,17,64} {
+		a,_ := j.Deterministic(rng,n)
+		b,_ := j.Deterministic(rng,n)
+		if got,want := j.Multiply(a,b),naiveJetMul(j,a,b); !got.Equal(want) { t.Fatalf("length %d: %v*%v = %v, want %v",n,a,b,got,want) }
+		if got,want := j.Multiply(a,a),naiveJetMul(j,a,a); !got.Equal(want) { t.Fatalf("length %d: square of %v = %v, want %v",n,a,got,want) }
+		if got,want := j.Exp(a,[]byte{5}),naiveJetMul(j,a,naiveJetMul(j,a,naiveJetMul(j,a,naiveJetMul(j,a,a)))); !got.Equal(want) {
+			t.Fatalf("length %d: a^5 = %v, want %v",n,got,want)
+		}
+	}
+}
+
+func TestJetInverse(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	j := testJet
+	for _,n := range []int{1,2,3,5,8} {
+		for i := 0; i<8; i++ {
+			a,_ := j.Deterministic(rng,n)
+			inv := j.Inverse(a)
+			if inv==nil || len(inv)!=n { t.Fatalf("length %d: no inverse of %v",n,a) }
+			if p := naiveJetMul(j,a,inv); !p.Equal(jetOne(n)) { t.Fatalf("length %d: %v * %v = %v",n,a,inv,p) }
+		}
+		if j.Inverse(nilpotent(rng,j,n))!=nil { t.Errorf("length %d: inverse of a non-unit",n) }
+	}
+}
+
+func TestJetExpLog(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	j := testJet
+	for _,n := range []int{1,2,3,5,8,17} {
+		a,b := nilpotent(rng,j,n),nilpotent(rng,j,n)
+		ea,e := j.Exponential(a)
+		if e!=nil { t.Fatal(e) }
+		eb,_ := j.Exponential(b)
+		if l,e := j.Logarithm(ea); e!=nil || !l.Equal(a) { t.Fatalf("length %d: log(exp(%v)) = %v, %v",n,a,l,e) }
+		if eab,_ := j.Exponential(j.Add(a,b)); !eab.Equal(j.Multiply(ea,eb)) { t.Fatalf("length %d: exp(a+b) != exp(a)*exp(b)",n) }
+		// exp(a) = E(a), with E the jet of exp(x) at 0.
+		E,_ := j.Exponential(j.Variable(new(big.Int),n))
+		if c,e := j.Compose(E,a); e!=nil || !c.Equal(ea) { t.Fatalf("length %d: Compose(exp,a) = %v, %v",n,c,e) }
+		if n>1 {
+			u,_ := j.Deterministic(rng,n)
+			if _,e := j.Exponential(u); e!=ErrNotNilpotent { t.Errorf("Exponential of a unit: %v",e) }
+			if _,e := j.Logarithm(a); e==nil { t.Error("Logarithm with constant term zero") }
+		}
+	}
+	if _,e := (Jet{big.NewInt(3)}).Exponential(MultiComp{new(big.Int),big.NewInt(1),new(big.Int),new(big.Int)}); e==nil { t.Error("length 4 accepted modulo 3") }
+}
+
+func TestJetCompose(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	j := testJet
+	for _,n := range []int{1,2,5,8} {
+		for _,m := range []int{1,3,6} {
+			f,_ := j.Deterministic(rng,m)
+			g := nilpotent(rng,j,n)
+			// sum(f[i]*g^i) by the definition.
+			want,pow := zeroes(n),jetOne(n)
+			for i := range f {
+				for k := range want {
+					want[k].Add(want[k],new(big.Int).Mul(f[i],pow[k])).Mod(want[k],j.Mod)
+				}
+				pow = naiveJetMul(j,pow,g)
+			}
+			if got,e := j.Compose(f,g); e!=nil || !got.Equal(want) { t.Fatalf("Compose(%v,%v) = %v, want %v",f,g,got,want) }
+		}
+	}
+	if _,e := j.Compose(jetOne(2),jetOne(2)); e!=ErrNotNilpotent { t.Errorf("inner jet with a constant term: %v",e) }
+}
+
+func TestJetDerivatives(t *testing.T) {
+	j := testJet
+	// f(x) = x^3 - 2x + 7 at x0 = 2: f = 11, f' = 10, f'' = 12, f''' = 6.
+	v := j.Variable(big.NewInt(2),5)
+	seven := zeroes(5)
+	seven[0].SetUint64(7)
+	f := j.Add(j.Sub(j.Multiply(v,j.Multiply(v,v)),j.Add(v,v)),seven)
+	for k,want := range []int64{11,10,12,6,0} {
+		if d := j.Derivatives(f)[k]; d.Cmp(big.NewInt(want))!=0 { t.Errorf("f^(%d)(2) = %v, want %d",k,d,want) }
+	}
+	// The derivative and the integral undo each other below the top coefficient.
+	a,_ := j.Deterministic(rand.New(rand.NewSource(5)),6)
+	in,e := j.Integral(a)
+	if e!=nil { t.Fatal(e) }
+	if d := j.Derivative(in); !d[:5].Equal(a[:5]) || in[0].Sign()!=0 { t.Errorf("Derivative(Integral(%v)) = %v",a,d) }
+	if v := j.Variable(big.NewInt(-1),1); len(v)!=1 || v[0].Cmp(new(big.Int).Sub(j.Mod,big.NewInt(1)))!=0 { t.Errorf("Variable(-1,1) = %v",v) }
+}
@@ -0,0 +1,200 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package hypercomplex
+
+import "math/big"
+import "math/bits"
+import "errors"
+
+// Up to 512 bits, as 64-bit little-endian words.
+type limbs [8]uint64
+
+// A MultiComp in Montgomery form.
+type fvec []limbs
+
+/*
+A fixed-width limb backend for a Modulus.
+
+Coefficients are held as 4, 6 or 8 64-bit limbs in Montgomery form, and the
+coefficient products run through unrolled kernels (assembly on amd64, using
+MULX/ADCX/ADOX when the CPU supports them). Inputs and outputs are ordinary
+MultiComp values, so a Fixed can replace the Modulus it was made from.
+Only Multiply and Exp are overridden.
+*/
+type Fixed struct{
+	Modulus
+	n int
+	p,r2 limbs
+	pinv uint64
+}
+
+/*
+Creates the fixed-width backend for m.
+The modulus must be odd and at most 512 bits long.
+*/
+func (m Modulus) Fixed() (*Fixed,error) {
+	if m.Mod.Sign()<=0 || m.Mod.Bit(0)==0 { return nil,errors.New("Modulus must be odd and positive") }
+	f := &Fixed{Modulus:m}
+	switch l := m.Mod.BitLen(); {
+	case l<=256: f.n = 4
+	case l<=384: f.n = 6
+	case l<=512: f.n = 8
+	default: return nil,errors.New("Modulus too large")
+	}
+	f.p = f.load(m.Mod)
+
+	// pinv = -p^-1 mod 2^64, by Newton iteration.
+	inv := f.p[0]
+	for i := 0; i<5; i++ { inv *= 2-f.p[0]*inv }
+	f.pinv = -inv
+
+	r2 := new(big.Int).Lsh(big.NewInt(1),uint(128*f.n))
+	f.r2 = f.load(r2.Mod(r2,m.Mod))
+	return f,nil
+}
+
+func (f *Fixed) load(x *big.Int) (l limbs) {
+	buf := make([]byte,8*f.n)
+	x.FillBytes(buf)
+	for i := 0; i<f.n; i++ {
+		for _,b := range buf[len(buf)-8*i-8:len(buf)-8*i] { l[i] = l[i]<<8|uint64(b) }
+	}
+	return
+}
+func (f *Fixed) store(l *limbs) *big.Int {
+	buf := make([]byte,8*f.n)
+	for i := 0; i<f.n; i++ {
+		w := l[i]
+		for j := len(buf)-8*i-1; j>=len(buf)-8*i-8; j-- { buf[j] = byte(w); w >>= 8 }
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+func (f *Fixed) mul(z,x,y *limbs) { montMul(f.n,z,x,y,&f.p,f.pinv) }
+
+func (f *Fixed) to(a MultiComp) fvec {
+	v := make(fvec,len(a))
+	for i,c := range a {
+		c = new(big.Int).Mod(c,f.Mod)
+		l := f.load(c)
+		f.mul(&v[i],&l,&f.r2)
+	}
+	return v
+}
+func (f *Fixed) from(v fvec) MultiComp {
+	one := limbs{1}
+	a := make(MultiComp,len(v))
+	for i := range v {
+		var l limbs
+		f.mul(&l,&v[i],&one)
+		a[i] = f.store(&l)
+	}
+	return a
+}
+
+func (f *Fixed) add(z,x,y *limbs) {
+	var s,d limbs
+	var c,b uint64
+	for i := 0; i<f.n; i++ { s[i],c = bits.Add64(x[i],y[i],c) }
+	for i := 0; i<f.n; i++ { d[i],b = bits.Sub64(s[i],f.p[i],b) }
+	if c==0 && b!=0 { *z = s } else { *z = d }
+}
+func (f *Fixed) sub(z,x,y *limbs) {
+	var d,s limbs
+	var b,c uint64
+	for i := 0; i<f.n; i++ { d[i],b = bits.Sub64(x[i],y[i],b) }
+	for i := 0; i<f.n; i++ { s[i],c = bits.Add64(d[i],f.p[i],c) }
+	if b!=0 { *z = s } else { *z = d }
+}
+
+// Same recursion as Modulus.Multiply.
+func (f *Fixed) mulRec(a,b fvec) fvec {
+	L := len(a)/2
+	if L==0 {
+		c := make(fvec,1)
+		f.mul(&c[0],&a[0],&b[0])
+		return c
+	}
+	ar,ai := a[:L],a[L:]
+	br,bi := b[:L],b[L:]
+	c := make(fvec,2*L)
+	rr,ii := f.mulRec(ar,br),f.mulRec(ai,bi)
+	ri,ir := f.mulRec(ar,bi),f.mulRec(ai,br)
+	for i := 0; i<L; i++ {
+		f.sub(&c[i],&rr[i],&ii[i])
+		f.add(&c[L+i],&ri[i],&ir[i])
+	}
+	return c
+}
+
+func (f *Fixed) Multiply(a,b MultiComp) MultiComp {
+	return f.from(f.mulRec(f.to(a),f.to(b)))
+}
+func (f *Fixed) Exp(g MultiComp, exp []byte) MultiComp {
+	gv := f.to(g)
+	v := make(fvec,len(g))
+	f.mul(&v[0],&limbs{1},&f.r2)
+	for _,k := range exp {
+		for j := 0; j<8; j++ {
+			v = f.mulRec(v,v)
+			if (k&0x80)==0x80 {
+				v = f.mulRec(v,gv)
+			}
+			k <<= 1
+		}
+	}
+	return f.from(v)
+}
+
+/*
+Portable CIOS Montgomery multiplication: z = x*y/2^(64n) mod p.
+The accumulator window of row i is t[i..i+n+1], as in the assembly.
+*/
+func montMulGeneric(n int, z,x,y,p *limbs, pinv uint64) {
+	var t [18]uint64
+	row := func(o int, a *limbs, w uint64) {
+		var c,cc uint64
+		for j := 0; j<n; j++ {
+			hi,lo := bits.Mul64(a[j],w)
+			lo,cc = bits.Add64(lo,c,0)
+			hi += cc
+			t[o+j],cc = bits.Add64(t[o+j],lo,0)
+			c = hi+cc
+		}
+		t[o+n],cc = bits.Add64(t[o+n],c,0)
+		t[o+n+1] += cc
+	}
+	for i := 0; i<n; i++ {
+		row(i,x,y[i])
+		row(i,p,t[i]*pinv)
+	}
+	var d limbs
+	var b uint64
+	for j := 0; j<n; j++ { d[j],b = bits.Sub64(t[n+j],p[j],b) }
+	_,b = bits.Sub64(t[2*n],0,b)
+	for j := 0; j<n; j++ {
+		if b!=0 { z[j] = t[n+j] } else { z[j] = d[j] }
+	}
+}
@@ -0,0 +1,64 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+//go:build amd64
+
+//go:generate go run limbs_gen.go
+
+package hypercomplex
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+//go:noescape
+func montMul4(z, x, y, p *limbs, pinv uint64)
+//go:noescape
+func montMul6(z, x, y, p *limbs, pinv uint64)
+//go:noescape
+func montMul8(z, x, y, p *limbs, pinv uint64)
+//go:noescape
+func montMul4Adx(z, x, y, p *limbs, pinv uint64)
+//go:noescape
+func montMul6Adx(z, x, y, p *limbs, pinv uint64)
+//go:noescape
+func montMul8Adx(z, x, y, p *limbs, pinv uint64)
+
+// MULX is BMI2, ADCX/ADOX are ADX. Both are flagged in leaf 7, EBX.
+var hasADX = func() bool {
+	max,_,_,_ := cpuid(0,0)
+	if max<7 { return false }
+	_,ebx,_,_ := cpuid(7,0)
+	return ebx&(1<<8)!=0 && ebx&(1<<19)!=0
+}()
+
+func montMul(n int, z,x,y,p *limbs, pinv uint64) {
+	switch {
+	case n==4 && hasADX: montMul4Adx(z,x,y,p,pinv)
+	case n==6 && hasADX: montMul6Adx(z,x,y,p,pinv)
+	case n==8 && hasADX: montMul8Adx(z,x,y,p,pinv)
+	case n==4: montMul4(z,x,y,p,pinv)
+	case n==6: montMul6(z,x,y,p,pinv)
+	case n==8: montMul8(z,x,y,p,pinv)
+	default: montMulGeneric(n,z,x,y,p,pinv)
+	}
+}
@@ -0,0 +1,3712 @@
+// Code generated by limbs_gen.go. DO NOT EDIT.
+
+//go:build amd64
+
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func montMul4(z, x, y, p *limbs, pinv uint64)
+TEXT ·montMul4(SB), NOSPLIT, $80-40
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DI
+	MOVQ p+24(FP), CX
+	MOVQ pinv+32(FP), R13
+	XORQ AX, AX
+	MOVQ AX, 0(SP)
+	MOVQ AX, 8(SP)
+	MOVQ AX, 16(SP)
+	MOVQ AX, 24(SP)
+	MOVQ AX, 32(SP)
+	MOVQ AX, 40(SP)
+	MOVQ AX, 48(SP)
+	MOVQ AX, 56(SP)
+	MOVQ AX, 64(SP)
+	MOVQ AX, 72(SP)
+	MOVQ 0(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 0(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 32(SP)
+	ADCQ $0, 40(SP)
+	MOVQ 0(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 0(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 32(SP)
+	ADCQ $0, 40(SP)
+	MOVQ 8(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 40(SP)
+	ADCQ $0, 48(SP)
+	MOVQ 8(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 40(SP)
+	ADCQ $0, 48(SP)
+	MOVQ 16(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 48(SP)
+	ADCQ $0, 56(SP)
+	MOVQ 16(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 48(SP)
+	ADCQ $0, 56(SP)
+	MOVQ 24(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 56(SP)
+	ADCQ $0, 64(SP)
+	MOVQ 24(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 56(SP)
+	ADCQ $0, 64(SP)
+	MOVQ z+0(FP), DI
+	MOVQ 32(SP), R9
+	SUBQ 0(CX), R9
+	MOVQ R9, 0(DI)
+	MOVQ 40(SP), R9
+	SBBQ 8(CX), R9
+	MOVQ R9, 8(DI)
+	MOVQ 48(SP), R9
+	SBBQ 16(CX), R9
+	MOVQ R9, 16(DI)
+	MOVQ 56(SP), R9
+	SBBQ 24(CX), R9
+	MOVQ R9, 24(DI)
+	MOVQ 64(SP), R9
+	SBBQ $0, R9
+	MOVQ 0(DI), R9
+	CMOVQCS 32(SP), R9
+	MOVQ R9, 0(DI)
+	MOVQ 8(DI), R9
+	CMOVQCS 40(SP), R9
+	MOVQ R9, 8(DI)
+	MOVQ 16(DI), R9
+	CMOVQCS 48(SP), R9
+	MOVQ R9, 16(DI)
+	MOVQ 24(DI), R9
+	CMOVQCS 56(SP), R9
+	MOVQ R9, 24(DI)
+	RET
+
+// func montMul4Adx(z, x, y, p *limbs, pinv uint64)
+TEXT ·montMul4Adx(SB), NOSPLIT, $80-40
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DI
+	MOVQ p+24(FP), CX
+	MOVQ pinv+32(FP), R13
+	XORQ AX, AX
+	MOVQ AX, 0(SP)
+	MOVQ AX, 8(SP)
+	MOVQ AX, 16(SP)
+	MOVQ AX, 24(SP)
+	MOVQ AX, 32(SP)
+	MOVQ AX, 40(SP)
+	MOVQ AX, 48(SP)
+	MOVQ AX, 56(SP)
+	MOVQ AX, 64(SP)
+	MOVQ AX, 72(SP)
+	MOVQ 0(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 0(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 0(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 8(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 16(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MOVQ 32(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MOVQ 40(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 40(SP)
+	MOVQ 0(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 0(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 0(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 8(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 16(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MOVQ 32(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MOVQ 40(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 40(SP)
+	MOVQ 8(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 8(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 16(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 24(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MOVQ 40(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MOVQ 48(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 48(SP)
+	MOVQ 8(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 8(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 16(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 24(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MOVQ 40(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MOVQ 48(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 48(SP)
+	MOVQ 16(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 16(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MOVQ 48(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MOVQ 56(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 56(SP)
+	MOVQ 16(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 16(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MOVQ 48(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MOVQ 56(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 56(SP)
+	MOVQ 24(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 24(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MOVQ 56(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MOVQ 64(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 64(SP)
+	MOVQ 24(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 24(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MOVQ 56(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MOVQ 64(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 64(SP)
+	MOVQ z+0(FP), DI
+	MOVQ 32(SP), R9
+	SUBQ 0(CX), R9
+	MOVQ R9, 0(DI)
+	MOVQ 40(SP), R9
+	SBBQ 8(CX), R9
+	MOVQ R9, 8(DI)
+	MOVQ 48(SP), R9
+	SBBQ 16(CX), R9
+	MOVQ R9, 16(DI)
+	MOVQ 56(SP), R9
+	SBBQ 24(CX), R9
+	MOVQ R9, 24(DI)
+	MOVQ 64(SP), R9
+	SBBQ $0, R9
+	MOVQ 0(DI), R9
+	CMOVQCS 32(SP), R9
+	MOVQ R9, 0(DI)
+	MOVQ 8(DI), R9
+	CMOVQCS 40(SP), R9
+	MOVQ R9, 8(DI)
+	MOVQ 16(DI), R9
+	CMOVQCS 48(SP), R9
+	MOVQ R9, 16(DI)
+	MOVQ 24(DI), R9
+	CMOVQCS 56(SP), R9
+	MOVQ R9, 24(DI)
+	RET
+
+// func montMul6(z, x, y, p *limbs, pinv uint64)
+TEXT ·montMul6(SB), NOSPLIT, $112-40
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DI
+	MOVQ p+24(FP), CX
+	MOVQ pinv+32(FP), R13
+	XORQ AX, AX
+	MOVQ AX, 0(SP)
+	MOVQ AX, 8(SP)
+	MOVQ AX, 16(SP)
+	MOVQ AX, 24(SP)
+	MOVQ AX, 32(SP)
+	MOVQ AX, 40(SP)
+	MOVQ AX, 48(SP)
+	MOVQ AX, 56(SP)
+	MOVQ AX, 64(SP)
+	MOVQ AX, 72(SP)
+	MOVQ AX, 80(SP)
+	MOVQ AX, 88(SP)
+	MOVQ AX, 96(SP)
+	MOVQ AX, 104(SP)
+	MOVQ 0(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 0(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 48(SP)
+	ADCQ $0, 56(SP)
+	MOVQ 0(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 0(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 48(SP)
+	ADCQ $0, 56(SP)
+	MOVQ 8(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 56(SP)
+	ADCQ $0, 64(SP)
+	MOVQ 8(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 56(SP)
+	ADCQ $0, 64(SP)
+	MOVQ 16(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 64(SP)
+	ADCQ $0, 72(SP)
+	MOVQ 16(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 64(SP)
+	ADCQ $0, 72(SP)
+	MOVQ 24(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 72(SP)
+	ADCQ $0, 80(SP)
+	MOVQ 24(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 72(SP)
+	ADCQ $0, 80(SP)
+	MOVQ 32(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 80(SP)
+	ADCQ $0, 88(SP)
+	MOVQ 32(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 80(SP)
+	ADCQ $0, 88(SP)
+	MOVQ 40(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 88(SP)
+	ADCQ $0, 96(SP)
+	MOVQ 40(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 88(SP)
+	ADCQ $0, 96(SP)
+	MOVQ z+0(FP), DI
+	MOVQ 48(SP), R9
+	SUBQ 0(CX), R9
+	MOVQ R9, 0(DI)
+	MOVQ 56(SP), R9
+	SBBQ 8(CX), R9
+	MOVQ R9, 8(DI)
+	MOVQ 64(SP), R9
+	SBBQ 16(CX), R9
+	MOVQ R9, 16(DI)
+	MOVQ 72(SP), R9
+	SBBQ 24(CX), R9
+	MOVQ R9, 24(DI)
+	MOVQ 80(SP), R9
+	SBBQ 32(CX), R9
+	MOVQ R9, 32(DI)
+	MOVQ 88(SP), R9
+	SBBQ 40(CX), R9
+	MOVQ R9, 40(DI)
+	MOVQ 96(SP), R9
+	SBBQ $0, R9
+	MOVQ 0(DI), R9
+	CMOVQCS 48(SP), R9
+	MOVQ R9, 0(DI)
+	MOVQ 8(DI), R9
+	CMOVQCS 56(SP), R9
+	MOVQ R9, 8(DI)
+	MOVQ 16(DI), R9
+	CMOVQCS 64(SP), R9
+	MOVQ R9, 16(DI)
+	MOVQ 24(DI), R9
+	CMOVQCS 72(SP), R9
+	MOVQ R9, 24(DI)
+	MOVQ 32(DI), R9
+	CMOVQCS 80(SP), R9
+	MOVQ R9, 32(DI)
+	MOVQ 40(DI), R9
+	CMOVQCS 88(SP), R9
+	MOVQ R9, 40(DI)
+	RET
+
+// func montMul6Adx(z, x, y, p *limbs, pinv uint64)
+TEXT ·montMul6Adx(SB), NOSPLIT, $112-40
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DI
+	MOVQ p+24(FP), CX
+	MOVQ pinv+32(FP), R13
+	XORQ AX, AX
+	MOVQ AX, 0(SP)
+	MOVQ AX, 8(SP)
+	MOVQ AX, 16(SP)
+	MOVQ AX, 24(SP)
+	MOVQ AX, 32(SP)
+	MOVQ AX, 40(SP)
+	MOVQ AX, 48(SP)
+	MOVQ AX, 56(SP)
+	MOVQ AX, 64(SP)
+	MOVQ AX, 72(SP)
+	MOVQ AX, 80(SP)
+	MOVQ AX, 88(SP)
+	MOVQ AX, 96(SP)
+	MOVQ AX, 104(SP)
+	MOVQ 0(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 0(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 0(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 8(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 16(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MOVQ 48(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MOVQ 56(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 56(SP)
+	MOVQ 0(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 0(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 0(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 8(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 16(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MOVQ 48(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MOVQ 56(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 56(SP)
+	MOVQ 8(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 8(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 16(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 24(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MOVQ 56(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MOVQ 64(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 64(SP)
+	MOVQ 8(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 8(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 16(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 24(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MOVQ 56(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MOVQ 64(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 64(SP)
+	MOVQ 16(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 16(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MOVQ 64(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MOVQ 72(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 72(SP)
+	MOVQ 16(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 16(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MOVQ 64(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MOVQ 72(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 72(SP)
+	MOVQ 24(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 24(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MOVQ 72(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MOVQ 80(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 80(SP)
+	MOVQ 24(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 24(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MOVQ 72(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MOVQ 80(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 80(SP)
+	MOVQ 32(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 32(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 72(SP)
+	MOVQ 80(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 80(SP)
+	MOVQ 88(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 88(SP)
+	MOVQ 32(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 32(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 72(SP)
+	MOVQ 80(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 80(SP)
+	MOVQ 88(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 88(SP)
+	MOVQ 40(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 40(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 80(SP)
+	MOVQ 88(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 88(SP)
+	MOVQ 96(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 96(SP)
+	MOVQ 40(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 40(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 80(SP)
+	MOVQ 88(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 88(SP)
+	MOVQ 96(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 96(SP)
+	MOVQ z+0(FP), DI
+	MOVQ 48(SP), R9
+	SUBQ 0(CX), R9
+	MOVQ R9, 0(DI)
+	MOVQ 56(SP), R9
+	SBBQ 8(CX), R9
+	MOVQ R9, 8(DI)
+	MOVQ 64(SP), R9
+	SBBQ 16(CX), R9
+	MOVQ R9, 16(DI)
+	MOVQ 72(SP), R9
+	SBBQ 24(CX), R9
+	MOVQ R9, 24(DI)
+	MOVQ 80(SP), R9
+	SBBQ 32(CX), R9
+	MOVQ R9, 32(DI)
+	MOVQ 88(SP), R9
+	SBBQ 40(CX), R9
+	MOVQ R9, 40(DI)
+	MOVQ 96(SP), R9
+	SBBQ $0, R9
+	MOVQ 0(DI), R9
+	CMOVQCS 48(SP), R9
+	MOVQ R9, 0(DI)
+	MOVQ 8(DI), R9
+	CMOVQCS 56(SP), R9
+	MOVQ R9, 8(DI)
+	MOVQ 16(DI), R9
+	CMOVQCS 64(SP), R9
+	MOVQ R9, 16(DI)
+	MOVQ 24(DI), R9
+	CMOVQCS 72(SP), R9
+	MOVQ R9, 24(DI)
+	MOVQ 32(DI), R9
+	CMOVQCS 80(SP), R9
+	MOVQ R9, 32(DI)
+	MOVQ 40(DI), R9
+	CMOVQCS 88(SP), R9
+	MOVQ R9, 40(DI)
+	RET
+
+// func montMul8(z, x, y, p *limbs, pinv uint64)
+TEXT ·montMul8(SB), NOSPLIT, $144-40
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DI
+	MOVQ p+24(FP), CX
+	MOVQ pinv+32(FP), R13
+	XORQ AX, AX
+	MOVQ AX, 0(SP)
+	MOVQ AX, 8(SP)
+	MOVQ AX, 16(SP)
+	MOVQ AX, 24(SP)
+	MOVQ AX, 32(SP)
+	MOVQ AX, 40(SP)
+	MOVQ AX, 48(SP)
+	MOVQ AX, 56(SP)
+	MOVQ AX, 64(SP)
+	MOVQ AX, 72(SP)
+	MOVQ AX, 80(SP)
+	MOVQ AX, 88(SP)
+	MOVQ AX, 96(SP)
+	MOVQ AX, 104(SP)
+	MOVQ AX, 112(SP)
+	MOVQ AX, 120(SP)
+	MOVQ AX, 128(SP)
+	MOVQ AX, 136(SP)
+	MOVQ 0(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 0(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 64(SP)
+	ADCQ $0, 72(SP)
+	MOVQ 0(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 0(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 64(SP)
+	ADCQ $0, 72(SP)
+	MOVQ 8(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 72(SP)
+	ADCQ $0, 80(SP)
+	MOVQ 8(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 8(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 72(SP)
+	ADCQ $0, 80(SP)
+	MOVQ 16(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 80(SP)
+	ADCQ $0, 88(SP)
+	MOVQ 16(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 16(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 80(SP)
+	ADCQ $0, 88(SP)
+	MOVQ 24(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 88(SP)
+	ADCQ $0, 96(SP)
+	MOVQ 24(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 24(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 88(SP)
+	ADCQ $0, 96(SP)
+	MOVQ 32(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 88(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 96(SP)
+	ADCQ $0, 104(SP)
+	MOVQ 32(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 32(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 88(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 96(SP)
+	ADCQ $0, 104(SP)
+	MOVQ 40(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 88(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 96(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 104(SP)
+	ADCQ $0, 112(SP)
+	MOVQ 40(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 40(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 88(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 96(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 104(SP)
+	ADCQ $0, 112(SP)
+	MOVQ 48(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 88(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 96(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 104(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 112(SP)
+	ADCQ $0, 120(SP)
+	MOVQ 48(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 48(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 88(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 96(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 104(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 112(SP)
+	ADCQ $0, 120(SP)
+	MOVQ 56(DI), BX
+	XORQ R12, R12
+	MOVQ 0(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 88(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 96(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 104(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(SI), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 112(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 120(SP)
+	ADCQ $0, 128(SP)
+	MOVQ 56(SP), BX
+	IMULQ R13, BX
+	XORQ R12, R12
+	MOVQ 0(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 56(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 8(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 64(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 16(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 72(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 24(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 80(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 32(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 88(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 40(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 96(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 48(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 104(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	MOVQ 56(CX), AX
+	MULQ BX
+	ADDQ R12, AX
+	ADCQ $0, DX
+	ADDQ AX, 112(SP)
+	ADCQ $0, DX
+	MOVQ DX, R12
+	ADDQ R12, 120(SP)
+	ADCQ $0, 128(SP)
+	MOVQ z+0(FP), DI
+	MOVQ 64(SP), R9
+	SUBQ 0(CX), R9
+	MOVQ R9, 0(DI)
+	MOVQ 72(SP), R9
+	SBBQ 8(CX), R9
+	MOVQ R9, 8(DI)
+	MOVQ 80(SP), R9
+	SBBQ 16(CX), R9
+	MOVQ R9, 16(DI)
+	MOVQ 88(SP), R9
+	SBBQ 24(CX), R9
+	MOVQ R9, 24(DI)
+	MOVQ 96(SP), R9
+	SBBQ 32(CX), R9
+	MOVQ R9, 32(DI)
+	MOVQ 104(SP), R9
+	SBBQ 40(CX), R9
+	MOVQ R9, 40(DI)
+	MOVQ 112(SP), R9
+	SBBQ 48(CX), R9
+	MOVQ R9, 48(DI)
+	MOVQ 120(SP), R9
+	SBBQ 56(CX), R9
+	MOVQ R9, 56(DI)
+	MOVQ 128(SP), R9
+	SBBQ $0, R9
+	MOVQ 0(DI), R9
+	CMOVQCS 64(SP), R9
+	MOVQ R9, 0(DI)
+	MOVQ 8(DI), R9
+	CMOVQCS 72(SP), R9
+	MOVQ R9, 8(DI)
+	MOVQ 16(DI), R9
+	CMOVQCS 80(SP), R9
+	MOVQ R9, 16(DI)
+	MOVQ 24(DI), R9
+	CMOVQCS 88(SP), R9
+	MOVQ R9, 24(DI)
+	MOVQ 32(DI), R9
+	CMOVQCS 96(SP), R9
+	MOVQ R9, 32(DI)
+	MOVQ 40(DI), R9
+	CMOVQCS 104(SP), R9
+	MOVQ R9, 40(DI)
+	MOVQ 48(DI), R9
+	CMOVQCS 112(SP), R9
+	MOVQ R9, 48(DI)
+	MOVQ 56(DI), R9
+	CMOVQCS 120(SP), R9
+	MOVQ R9, 56(DI)
+	RET
+
+// func montMul8Adx(z, x, y, p *limbs, pinv uint64)
+TEXT ·montMul8Adx(SB), NOSPLIT, $144-40
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DI
+	MOVQ p+24(FP), CX
+	MOVQ pinv+32(FP), R13
+	XORQ AX, AX
+	MOVQ AX, 0(SP)
+	MOVQ AX, 8(SP)
+	MOVQ AX, 16(SP)
+	MOVQ AX, 24(SP)
+	MOVQ AX, 32(SP)
+	MOVQ AX, 40(SP)
+	MOVQ AX, 48(SP)
+	MOVQ AX, 56(SP)
+	MOVQ AX, 64(SP)
+	MOVQ AX, 72(SP)
+	MOVQ AX, 80(SP)
+	MOVQ AX, 88(SP)
+	MOVQ AX, 96(SP)
+	MOVQ AX, 104(SP)
+	MOVQ AX, 112(SP)
+	MOVQ AX, 120(SP)
+	MOVQ AX, 128(SP)
+	MOVQ AX, 136(SP)
+	MOVQ 0(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 0(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 0(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 8(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 16(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 48(SI), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 56(SI), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MOVQ 64(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MOVQ 72(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 72(SP)
+	MOVQ 0(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 0(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 0(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 8(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 16(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 48(CX), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 56(CX), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MOVQ 64(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MOVQ 72(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 72(SP)
+	MOVQ 8(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 8(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 16(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 24(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 48(SI), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 56(SI), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MOVQ 72(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MOVQ 80(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 80(SP)
+	MOVQ 8(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 8(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 8(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 16(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 24(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 48(CX), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 56(CX), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MOVQ 72(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MOVQ 80(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 80(SP)
+	MOVQ 16(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 16(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MULXQ 48(SI), R9, R10
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MULXQ 56(SI), R9, R12
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 72(SP)
+	MOVQ 80(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 80(SP)
+	MOVQ 88(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 88(SP)
+	MOVQ 16(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 16(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 16(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 24(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 32(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MULXQ 48(CX), R9, R10
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MULXQ 56(CX), R9, R12
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 72(SP)
+	MOVQ 80(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 80(SP)
+	MOVQ 88(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 88(SP)
+	MOVQ 24(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 24(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MULXQ 48(SI), R9, R10
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MULXQ 56(SI), R9, R12
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 80(SP)
+	MOVQ 88(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 88(SP)
+	MOVQ 96(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 96(SP)
+	MOVQ 24(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 24(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 24(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 32(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 40(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MULXQ 48(CX), R9, R10
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MULXQ 56(CX), R9, R12
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 80(SP)
+	MOVQ 88(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 88(SP)
+	MOVQ 96(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 96(SP)
+	MOVQ 32(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 32(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 72(SP)
+	MULXQ 48(SI), R9, R10
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 80(SP)
+	MULXQ 56(SI), R9, R12
+	MOVQ 88(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 88(SP)
+	MOVQ 96(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 96(SP)
+	MOVQ 104(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 104(SP)
+	MOVQ 32(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 32(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 32(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 40(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 48(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 72(SP)
+	MULXQ 48(CX), R9, R10
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 80(SP)
+	MULXQ 56(CX), R9, R12
+	MOVQ 88(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 88(SP)
+	MOVQ 96(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 96(SP)
+	MOVQ 104(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 104(SP)
+	MOVQ 40(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 40(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 80(SP)
+	MULXQ 48(SI), R9, R10
+	MOVQ 88(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 88(SP)
+	MULXQ 56(SI), R9, R12
+	MOVQ 96(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 96(SP)
+	MOVQ 104(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 104(SP)
+	MOVQ 112(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 112(SP)
+	MOVQ 40(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 40(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 40(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 48(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 56(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 80(SP)
+	MULXQ 48(CX), R9, R10
+	MOVQ 88(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 88(SP)
+	MULXQ 56(CX), R9, R12
+	MOVQ 96(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 96(SP)
+	MOVQ 104(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 104(SP)
+	MOVQ 112(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 112(SP)
+	MOVQ 48(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 48(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 72(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 80(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 88(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 88(SP)
+	MULXQ 48(SI), R9, R10
+	MOVQ 96(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 96(SP)
+	MULXQ 56(SI), R9, R12
+	MOVQ 104(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 104(SP)
+	MOVQ 112(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 112(SP)
+	MOVQ 120(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 120(SP)
+	MOVQ 48(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 48(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 48(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 56(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 64(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 72(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 80(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 88(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 88(SP)
+	MULXQ 48(CX), R9, R10
+	MOVQ 96(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 96(SP)
+	MULXQ 56(CX), R9, R12
+	MOVQ 104(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 104(SP)
+	MOVQ 112(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 112(SP)
+	MOVQ 120(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 120(SP)
+	MOVQ 56(DI), DX
+	XORQ R8, R8
+	MULXQ 0(SI), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 56(SP)
+	MULXQ 8(SI), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MULXQ 16(SI), R9, R10
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MULXQ 24(SI), R9, R12
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 80(SP)
+	MULXQ 32(SI), R9, R10
+	MOVQ 88(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 88(SP)
+	MULXQ 40(SI), R9, R12
+	MOVQ 96(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 96(SP)
+	MULXQ 48(SI), R9, R10
+	MOVQ 104(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 104(SP)
+	MULXQ 56(SI), R9, R12
+	MOVQ 112(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 112(SP)
+	MOVQ 120(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 120(SP)
+	MOVQ 128(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 128(SP)
+	MOVQ 56(SP), DX
+	IMULQ R13, DX
+	XORQ R8, R8
+	MULXQ 0(CX), R9, R10
+	MOVQ 56(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R8, R11
+	MOVQ R11, 56(SP)
+	MULXQ 8(CX), R9, R12
+	MOVQ 64(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 64(SP)
+	MULXQ 16(CX), R9, R10
+	MOVQ 72(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 72(SP)
+	MULXQ 24(CX), R9, R12
+	MOVQ 80(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 80(SP)
+	MULXQ 32(CX), R9, R10
+	MOVQ 88(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 88(SP)
+	MULXQ 40(CX), R9, R12
+	MOVQ 96(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 96(SP)
+	MULXQ 48(CX), R9, R10
+	MOVQ 104(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R12, R11
+	MOVQ R11, 104(SP)
+	MULXQ 56(CX), R9, R12
+	MOVQ 112(SP), R11
+	ADCXQ R9, R11
+	ADOXQ R10, R11
+	MOVQ R11, 112(SP)
+	MOVQ 120(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R12, R11
+	MOVQ R11, 120(SP)
+	MOVQ 128(SP), R11
+	ADCXQ R8, R11
+	ADOXQ R8, R11
+	MOVQ R11, 128(SP)
+	MOVQ z+0(FP), DI
+	MOVQ 64(SP), R9
+	SUBQ 0(CX), R9
+	MOVQ R9, 0(DI)
+	MOVQ 72(SP), R9
+	SBBQ 8(CX), R9
+	MOVQ R9, 8(DI)
+	MOVQ 80(SP), R9
+	SBBQ 16(CX), R9
+	MOVQ R9, 16(DI)
+	MOVQ 88(SP), R9
+	SBBQ 24(CX), R9
+	MOVQ R9, 24(DI)
+	MOVQ 96(SP), R9
+	SBBQ 32(CX), R9
+	MOVQ R9, 32(DI)
+	MOVQ 104(SP), R9
+	SBBQ 40(CX), R9
+	MOVQ R9, 40(DI)
+	MOVQ 112(SP), R9
+	SBBQ 48(CX), R9
+	MOVQ R9, 48(DI)
+	MOVQ 120(SP), R9
+	SBBQ 56(CX), R9
+	MOVQ R9, 56(DI)
+	MOVQ 128(SP), R9
+	SBBQ $0, R9
+	MOVQ 0(DI), R9
+	CMOVQCS 64(SP), R9
+	MOVQ R9, 0(DI)
+	MOVQ 8(DI), R9
+	CMOVQCS 72(SP), R9
+	MOVQ R9, 8(DI)
+	MOVQ 16(DI), R9
+	CMOVQCS 80(SP), R9
+	MOVQ R9, 16(DI)
+	MOVQ 24(DI), R9
+	CMOVQCS 88(SP), R9
+	MOVQ R9, 24(DI)
+	MOVQ 32(DI), R9
+	CMOVQCS 96(SP), R9
+	MOVQ R9, 32(DI)
+	MOVQ 40(DI), R9
+	CMOVQCS 104(SP), R9
+	MOVQ R9, 40(DI)
+	MOVQ 48(DI), R9
+	CMOVQCS 112(SP), R9
+	MOVQ R9, 48(DI)
+	MOVQ 56(DI), R9
+	CMOVQCS 120(SP), R9
+	MOVQ R9, 56(DI)
+	RET
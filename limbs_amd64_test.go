@@ -0,0 +1,67 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+//go:build amd64
+
+package hypercomplex
+
+import "testing"
+import "math/big"
+
+type montKernel func(z,x,y,p *limbs, pinv uint64)
+
+// Calls every assembly kernel directly, not through the dispatch in montMul.
+func TestMontMulKernels(t *testing.T) {
+	kernels := map[int][]montKernel{
+		4: {montMul4},
+		6: {montMul6},
+		8: {montMul8},
+	}
+	if hasADX {
+		kernels[4] = append(kernels[4],montMul4Adx)
+		kernels[6] = append(kernels[6],montMul6Adx)
+		kernels[8] = append(kernels[8],montMul8Adx)
+	} else {
+		t.Log("CPU lacks ADX/BMI2; MULX kernels not run")
+	}
+	for _,m := range fixedModuli(t) {
+		f,_ := m.Fixed()
+		pm1 := new(big.Int).Sub(m.Mod,big.NewInt(1))
+		for i := 0; i<32; i++ {
+			a := randMC(m,2)
+			switch i {
+			case 0: a[0],a[1] = pm1,pm1
+			case 1: a[0] = pm1
+			case 2: a[1] = big.NewInt(0)
+			}
+			x,y := f.load(a[0]),f.load(a[1])
+			var want limbs
+			montMulGeneric(f.n,&want,&x,&y,&f.p,f.pinv)
+			for k,kern := range kernels[f.n] {
+				var z limbs
+				kern(&z,&x,&y,&f.p,f.pinv)
+				if z!=want { t.Fatalf("%d limbs, kernel %d: %x*%x gives %v, want %v",f.n,k,a[0],a[1],z,want) }
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+//go:build ignore
+
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+/*
+Generates limbs_amd64.s.
+
+Every kernel is a fully unrolled CIOS Montgomery multiplication. The
+accumulator lives in a (2n+2)-word stack window: row i works on words
+i..i+n+1, so no shifting is needed and the result ends up in words n..2n.
+*/
+package main
+
+import "bytes"
+import "fmt"
+import "os"
+
+var w = new(bytes.Buffer)
+
+func p(format string, args ...interface{}) {
+	fmt.Fprintf(w,"\t"+format+"\n",args...)
+}
+
+func t(i int) string { return fmt.Sprintf("%d(SP)",8*i) }
+
+// row with MULX: t[o..o+n+1] += DX * src[0..n-1], CF and OF as two carry chains.
+func rowAdx(n, o int, src string) {
+	p("XORQ R8, R8")
+	hi := [2]string{"R10","R12"}
+	prev := "R8"
+	for j := 0; j<n; j++ {
+		p("MULXQ %d(%s), R9, %s",8*j,src,hi[j&1])
+		p("MOVQ %s, R11",t(o+j))
+		p("ADCXQ R9, R11")
+		p("ADOXQ %s, R11",prev)
+		p("MOVQ R11, %s",t(o+j))
+		prev = hi[j&1]
+	}
+	p("MOVQ %s, R11",t(o+n))
+	p("ADCXQ R8, R11")
+	p("ADOXQ %s, R11",prev)
+	p("MOVQ R11, %s",t(o+n))
+	p("MOVQ %s, R11",t(o+n+1))
+	p("ADCXQ R8, R11")
+	p("ADOXQ R8, R11")
+	p("MOVQ R11, %s",t(o+n+1))
+}
+
+// row with MULQ: t[o..o+n+1] += BX * src[0..n-1].
+func rowMul(n, o int, src string) {
+	p("XORQ R12, R12")
+	for j := 0; j<n; j++ {
+		p("MOVQ %d(%s), AX",8*j,src)
+		p("MULQ BX")
+		p("ADDQ R12, AX")
+		p("ADCQ $0, DX")
+		p("ADDQ AX, %s",t(o+j))
+		p("ADCQ $0, DX")
+		p("MOVQ DX, R12")
+	}
+	p("ADDQ R12, %s",t(o+n))
+	p("ADCQ $0, %s",t(o+n+1))
+}
+
+func kernel(n int, adx bool) {
+	name := fmt.Sprintf("montMul%d",n)
+	if adx { name += "Adx" }
+	frame := 8*(2*n+2)
+	fmt.Fprintf(w,"\n// func %s(z, x, y, p *limbs, pinv uint64)\n",name)
+	fmt.Fprintf(w,"TEXT ·%s(SB), NOSPLIT, $%d-40\n",name,frame)
+	p("MOVQ x+8(FP), SI")
+	p("MOVQ y+16(FP), DI")
+	p("MOVQ p+24(FP), CX")
+	p("MOVQ pinv+32(FP), R13")
+	p("XORQ AX, AX")
+	for i := 0; i<2*n+2; i++ { p("MOVQ AX, %s",t(i)) }
+	for i := 0; i<n; i++ {
+		if adx {
+			p("MOVQ %d(DI), DX",8*i)
+			rowAdx(n,i,"SI")
+			p("MOVQ %s, DX",t(i))
+			p("IMULQ R13, DX")
+			rowAdx(n,i,"CX")
+		} else {
+			p("MOVQ %d(DI), BX",8*i)
+			rowMul(n,i,"SI")
+			p("MOVQ %s, BX",t(i))
+			p("IMULQ R13, BX")
+			rowMul(n,i,"CX")
+		}
+	}
+	// z = t-p if that does not borrow, t otherwise.
+	p("MOVQ z+0(FP), DI")
+	for j := 0; j<n; j++ {
+		p("MOVQ %s, R9",t(n+j))
+		if j==0 {
+			p("SUBQ 0(CX), R9")
+		} else {
+			p("SBBQ %d(CX), R9",8*j)
+		}
+		p("MOVQ R9, %d(DI)",8*j)
+	}
+	p("MOVQ %s, R9",t(2*n))
+	p("SBBQ $0, R9")
+	for j := 0; j<n; j++ {
+		p("MOVQ %d(DI), R9",8*j)
+		p("CMOVQCS %s, R9",t(n+j))
+		p("MOVQ R9, %d(DI)",8*j)
+	}
+	p("RET")
+}
+
+func main() {
+	fmt.Fprintln(w,"// Code generated by limbs_gen.go. DO NOT EDIT.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w,"//go:build amd64")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w,"#include \"textflag.h\"")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w,"// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)")
+	fmt.Fprintln(w,"TEXT ·cpuid(SB), NOSPLIT, $0-24")
+	p("MOVL eaxArg+0(FP), AX")
+	p("MOVL ecxArg+4(FP), CX")
+	p("CPUID")
+	p("MOVL AX, eax+8(FP)")
+	p("MOVL BX, ebx+12(FP)")
+	p("MOVL CX, ecx+16(FP)")
+	p("MOVL DX, edx+20(FP)")
+	p("RET")
+	for _,n := range []int{4,6,8} {
+		kernel(n,false)
+		kernel(n,true)
+	}
+	if e := os.WriteFile("limbs_amd64.s",w.Bytes(),0644); e!=nil {
+		fmt.Fprintln(os.Stderr,e)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+import "crypto/rand"
+
+// A random element with coefficients in 0..P-1.
+func randMC(m Modulus, n int) MultiComp {
+	r := make(MultiComp,n)
+	for i := range r { r[i],_ = rand.Int(rand.Reader,m.Mod) }
+	return r
+}
+
+// An element with every coefficient equal to P-1.
+func maxMC(m Modulus, n int) MultiComp {
+	r := make(MultiComp,n)
+	for i := range r { r[i] = new(big.Int).Sub(m.Mod,big.NewInt(1)) }
+	return r
+}
+
+// Odd moduli that fill 4, 6 and 8 limbs, or nearly so.
+func fixedModuli(t *testing.T) []Modulus {
+	var ms []Modulus
+	for _,bits := range []int{192,255,256,300,383,384,450,511,512} {
+		p,e := rand.Prime(rand.Reader,bits)
+		if e!=nil { t.Fatal(e) }
+		all := new(big.Int).Lsh(big.NewInt(1),uint(bits))
+		ms = append(ms,Modulus{p},Modulus{all.Sub(all,big.NewInt(1))})
+	}
+	return ms
+}
+
+func TestFixedMultiply(t *testing.T) {
+	for _,m := range fixedModuli(t) {
+		f,e := m.Fixed()
+		if e!=nil { t.Fatal(e) }
+		for _,n := range []int{1,2,4,8} {
+			for i := 0; i<8; i++ {
+				a,b := randMC(m,n),randMC(m,n)
+				switch i {
+				case 0: a,b = maxMC(m,n),maxMC(m,n)
+				case 1: a = maxMC(m,n)
+				}
+				if got,want := f.Multiply(a,b),m.Multiply(a,b); !got.Equal(want) {
+					t.Fatalf("%d limbs, dim %d: %v*%v = %v, want %v",f.n,n,a,b,got,want)
+				}
+			}
+		}
+	}
+}
+
+func TestFixedExp(t *testing.T) {
+	for _,m := range fixedModuli(t) {
+		f,e := m.Fixed()
+		if e!=nil { t.Fatal(e) }
+		for _,g := range []MultiComp{randMC(m,4),maxMC(m,4),randMC(m,1)} {
+			exp := make([]byte,9)
+			rand.Read(exp)
+			if got,want := f.Exp(g,exp),m.Exp(g,exp); !got.Equal(want) {
+				t.Fatalf("%d limbs: %v^%x = %v, want %v",f.n,g,exp,got,want)
+			}
+		}
+	}
+}
+
+func TestFixedRejects(t *testing.T) {
+	for _,p := range []int64{0,-7,16} {
+		if _,e := (Modulus{big.NewInt(p)}).Fixed(); e==nil { t.Errorf("Fixed accepted %d",p) }
+	}
+	huge := new(big.Int).Lsh(big.NewInt(1),513)
+	if _,e := (Modulus{huge.Add(huge,big.NewInt(1))}).Fixed(); e==nil { t.Error("Fixed accepted a 514-bit modulus") }
+}
+
+// montMulGeneric computes x*y/R mod P with R = 2^(64n).
+func TestMontMulGeneric(t *testing.T) {
+	for _,m := range fixedModuli(t) {
+		f,_ := m.Fixed()
+		rinv := new(big.Int).Lsh(big.NewInt(1),uint(64*f.n))
+		rinv.ModInverse(rinv,m.Mod)
+		pm1 := new(big.Int).Sub(m.Mod,big.NewInt(1))
+		for i := 0; i<16; i++ {
+			a,b := randMC(m,2),randMC(m,2)
+			if i==0 { a[0],b[0] = pm1,pm1 }
+			x,y := f.load(a[0]),f.load(b[0])
+			var z limbs
+			montMulGeneric(f.n,&z,&x,&y,&f.p,f.pinv)
+			want := new(big.Int).Mul(a[0],b[0])
+			want.Mul(want,rinv).Mod(want,m.Mod)
+			if got := f.store(&z); got.Cmp(want)!=0 { t.Fatalf("%d limbs: got %x, want %x",f.n,got,want) }
+		}
+	}
+}
@@ -0,0 +1,220 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math/big"
+import "crypto/rand"
+import "io"
+import "sync"
+import "errors"
+
+/*
+Outsourced exponentiation in a Subgroup, after Hohenberger and Lysyanskaya
+("How to securely outsource cryptographic computations", 2005): a client
+computes u^a with two untrusted servers that do not collude, at the cost of
+a handful of local multiplications.
+
+The client draws precomputed pairs (k, g^k) from an ExpPool and rewrites
+
+	u^a = w^d * w^f * g^c * g^e
+	w = u*g^b, d random, f = a-d, c random, e = -a*b-c (mod Q)
+
+Server 1 is asked for w^d and server 2 for w^f; every query on its own is
+uniformly random, so neither server learns u or a. Both servers are asked for
+g^e and their answers must agree. Each server also gets two test queries
+(g^k1)^(k2/k1) with the known answer g^k2, which look just like the real
+query; a server that falsifies one of its answers is caught with probability
+2/3 and with certainty if it is the one asked for g^e.
+
+Answers are only checked to be well-formed (the right dimension, reduced
+coefficients), not to lie in the subgroup, which would cost a full
+exponentiation each. A server returning a wrong but well-formed answer for
+the real query therefore goes unnoticed with probability up to 1/3.
+
+u must be an element of the subgroup: otherwise w reveals the coset of u and
+the result is wrong.
+*/
+type ExpPool struct{
+	sg *Subgroup
+	mu sync.Mutex
+	pairs []expPair
+}
+
+type expPair struct{
+	k *big.Int
+	gk MultiComp
+}
+
+// Pool pairs used by one outsourced exponentiation.
+const expPairsPerCall = 10
+
+var ErrPoolEmpty = errors.New("Exponentiation pool is empty")
+var ErrServerCheat = errors.New("Server returned a wrong result")
+
+// Creates a pool with 'size' pairs, computed locally (eg. while idle).
+func (sg *Subgroup) NewExpPool(rand io.Reader, size int) (*ExpPool,error) {
+	p := &ExpPool{sg:sg}
+	return p,p.Refill(rand,size)
+}
+
+// Adds n freshly computed pairs.
+func (p *ExpPool) Refill(rand io.Reader, n int) error {
+	fresh := make([]expPair,0,n)
+	for i := 0; i<n; i++ {
+		k,e := p.sg.RandomScalar(rand)
+		if e!=nil { return e }
+		fresh = append(fresh,expPair{k,p.sg.pow(p.sg.G,k)})
+	}
+	p.mu.Lock()
+	p.pairs = append(p.pairs,fresh...)
+	p.mu.Unlock()
+	return nil
+}
+
+// Number of pairs left; each outsourced exponentiation uses ten.
+func (p *ExpPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.pairs)
+}
+
+func (p *ExpPool) take(n int) ([]expPair,error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.pairs)<n { return nil,ErrPoolEmpty }
+	// Copied out, as Refill appends into the same backing array.
+	r := append([]expPair(nil),p.pairs[len(p.pairs)-n:]...)
+	p.pairs = p.pairs[:len(p.pairs)-n]
+	return r,nil
+}
+
+type ExpQuery struct{
+	Base MultiComp
+	Exponent *big.Int
+}
+
+// An untrusted server answering Base^Exponent for every query.
+type ExpServer interface{
+	Exp(queries []ExpQuery) ([]MultiComp,error)
+}
+
+// An in-process ExpServer stand-in.
+type LocalServer struct{
+	Algebra
+}
+
+func (s LocalServer) Exp(queries []ExpQuery) ([]MultiComp,error) {
+	r := make([]MultiComp,len(queries))
+	for i,q := range queries {
+		r[i] = s.Algebra.Exp(q.Base,q.Exponent.Bytes())
+	}
+	return r,nil
+}
+
+// What the client checked for one outsourced exponentiation.
+type Receipt struct{
+	Queries [2]int // queries sent to each server
+	Tests [2]int // known-answer tests among them
+	Compared int // queries asked of both servers and compared
+}
+
+// Computes u^a through two servers; see ExpPool.
+func (p *ExpPool) Exp(rnd io.Reader, servers [2]ExpServer, u MultiComp, a *big.Int) (MultiComp,*Receipt,error) {
+	sg := p.sg
+	pairs,e := p.take(expPairsPerCall)
+	if e!=nil { return nil,nil,e }
+	b,c := pairs[0],pairs[1]
+	w := sg.Multiply(u,b.gk)
+	d,e := sg.RandomScalar(rnd)
+	if e!=nil { return nil,nil,e }
+	f := new(big.Int).Sub(a,d)
+	f.Mod(f,sg.Q)
+	ex := new(big.Int).Mul(a,b.k)
+	ex.Add(ex,c.k).Neg(ex).Mod(ex,sg.Q)
+	shared := ExpQuery{sg.G,ex}
+	parts := [2]ExpQuery{{w,d},{w,f}}
+	var results [2]MultiComp
+	var sharedRes [2]MultiComp
+	rc := new(Receipt)
+	for s := 0; s<2; s++ {
+		queries := []ExpQuery{shared,parts[s]}
+		expect := []MultiComp{nil,nil}
+		for t := 0; t<2; t++ {
+			k1,k2 := pairs[2+4*s+2*t],pairs[3+4*s+2*t]
+			inv := new(big.Int).ModInverse(k1.k,sg.Q)
+			if inv==nil { return nil,nil,errors.New("Degenerate pool pair") }
+			queries = append(queries,ExpQuery{k1.gk,inv.Mul(inv,k2.k).Mod(inv,sg.Q)})
+			expect = append(expect,k2.gk)
+		}
+		perm,e := shuffleIndex(rnd,len(queries))
+		if e!=nil { return nil,nil,e }
+		sent := make([]ExpQuery,len(queries))
+		for i,j := range perm { sent[i] = queries[j] }
+		ans,e := servers[s].Exp(sent)
+		if e!=nil { return nil,nil,e }
+		if len(ans)!=len(sent) { return nil,nil,ErrServerCheat }
+		got := make([]MultiComp,len(queries))
+		for i,j := range perm { got[j] = ans[i] }
+		for i := 2; i<len(got); i++ {
+			if !sameElement(got[i],expect[i]) { return nil,nil,ErrServerCheat }
+		}
+		if !sg.wellFormed(got[0]) || !sg.wellFormed(got[1]) { return nil,nil,ErrServerCheat }
+		sharedRes[s],results[s] = got[0],got[1]
+		rc.Queries[s],rc.Tests[s] = len(queries),len(queries)-2
+	}
+	if !sameElement(sharedRes[0],sharedRes[1]) { return nil,nil,ErrServerCheat }
+	rc.Compared = 1
+	r := sg.Multiply(sg.Multiply(results[0],results[1]),sg.Multiply(c.gk,sharedRes[0]))
+	return r,rc,nil
+}
+
+// Reports whether x has the dimension of G and reduced coefficients.
+func (sg *Subgroup) wellFormed(x MultiComp) bool {
+	if len(x)!=len(sg.G) { return false }
+	for _,c := range x {
+		if c==nil || c.Sign()<0 { return false }
+	}
+	y,e := sg.Decode(sg.Encode(x))
+	return e==nil && y.Equal(x)
+}
+
+func sameElement(a,b MultiComp) bool {
+	if len(a)!=len(b) { return false }
+	for i := range a {
+		if a[i]==nil || b[i]==nil { return false }
+	}
+	return a.Equal(b)
+}
+
+// A uniformly random permutation of 0..n-1.
+func shuffleIndex(rnd io.Reader, n int) ([]int,error) {
+	p := make([]int,n)
+	for i := range p { p[i] = i }
+	for i := n-1; i>0; i-- {
+		j,e := rand.Int(rnd,big.NewInt(int64(i+1)))
+		if e!=nil { return nil,e }
+		p[i],p[j.Int64()] = p[j.Int64()],p[i]
+	}
+	return p,nil
+}
@@ -0,0 +1,167 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "errors"
+import "math/big"
+import "math/rand"
+
+// Answers honestly, except for the queries picked by hit, where it returns lie(answer).
+type cheatServer struct{
+	LocalServer
+	hit func(q ExpQuery) bool
+	lie func(r MultiComp) MultiComp
+}
+
+func (c cheatServer) Exp(queries []ExpQuery) ([]MultiComp,error) {
+	r,_ := c.LocalServer.Exp(queries)
+	for i,q := range queries {
+		if c.hit(q) { r[i] = c.lie(r[i]) }
+	}
+	return r,nil
+}
+
+type failServer struct{ n int }
+
+func (f failServer) Exp(queries []ExpQuery) ([]MultiComp,error) {
+	if f.n<0 { return nil,errors.New("Unavailable") }
+	return make([]MultiComp,f.n),nil
+}
+
+func TestExpPool(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	pool,e := sg.NewExpPool(rng,3*expPairsPerCall)
+	if e!=nil { t.Fatal(e) }
+	loc := LocalServer{sg.Algebra}
+	servers := [2]ExpServer{loc,loc}
+	u := sg.pow(sg.G,big.NewInt(rng.Int63()))
+	for _,a := range []*big.Int{big.NewInt(rng.Int63()),new(big.Int).Sub(sg.Q,big.NewInt(1)),new(big.Int),new(big.Int).Lsh(sg.Q,3)} {
+		r,rc,e := pool.Exp(rng,servers,u,a)
+		if e!=nil { t.Fatal(e) }
+		if !r.Equal(sg.pow(u,a)) { t.Fatalf("u^%v = %v, want %v",a,r,sg.pow(u,a)) }
+		if *rc!=(Receipt{[2]int{4,4},[2]int{2,2},1}) { t.Fatalf("receipt %+v",*rc) }
+		if pool.Len()!=2*expPairsPerCall { t.Fatalf("%d pairs left, want %d",pool.Len(),2*expPairsPerCall) }
+		if e := pool.Refill(rng,expPairsPerCall); e!=nil { t.Fatal(e) }
+	}
+	for i := 0; i<3; i++ {
+		if _,_,e := pool.Exp(rng,servers,u,big.NewInt(2)); e!=nil { t.Fatal(e) }
+	}
+	if _,_,e := pool.Exp(rng,servers,sg.G,big.NewInt(2)); e!=ErrPoolEmpty { t.Fatalf("empty pool: %v",e) }
+}
+
+func TestExpPoolCheat(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	pool,e := sg.NewExpPool(rng,0)
+	if e!=nil { t.Fatal(e) }
+	loc := LocalServer{sg.Algebra}
+	outside,_ := sg.Deterministic(rng,len(sg.G))
+	lies := map[string]func(MultiComp) MultiComp{
+		"nil coefficient": func(r MultiComp) MultiComp { r = r.Copy(); r[1] = nil; return r },
+		"outside the subgroup": func(r MultiComp) MultiComp { return sg.Multiply(r,outside) },
+		"wrong dimension": func(r MultiComp) MultiComp { return r[:2] },
+		"unreduced": func(r MultiComp) MultiComp { r = r.Copy(); r[0] = new(big.Int).Add(r[0],sg.Algebra.(Modulus).Mod); return r },
+		"squared": func(r MultiComp) MultiComp { return sg.Multiply(r,r) },
+	}
+	// Only these are caught without comparing against another answer.
+	malformed := map[string]bool{"nil coefficient":true,"wrong dimension":true,"unreduced":true}
+	run := func(s0,s1 ExpServer) error {
+		if e := pool.Refill(rng,expPairsPerCall); e!=nil { t.Fatal(e) }
+		_,_,e := pool.Exp(rng,[2]ExpServer{s0,s1},sg.pow(sg.G,big.NewInt(5)),big.NewInt(1234567))
+		return e
+	}
+	for name,lie := range lies {
+		// Lying on every query, or on the shared one, is always caught.
+		all := cheatServer{loc,func(ExpQuery) bool { return true },lie}
+		shared := cheatServer{loc,func(q ExpQuery) bool { return q.Base.Equal(sg.G) },lie}
+		if e := run(all,loc); e!=ErrServerCheat { t.Errorf("%s on every query: %v",name,e) }
+		if e := run(loc,shared); e!=ErrServerCheat { t.Errorf("%s on the shared query: %v",name,e) }
+		if e := run(shared,shared); (e==ErrServerCheat)!=malformed[name] { t.Errorf("%s from both servers: %v",name,e) }
+	}
+	// A server that knows which query is the real one gets away with any
+	// well-formed answer.
+	for name,lie := range lies {
+		pool.Refill(rng,expPairsPerCall)
+		tests := make(map[string]bool)
+		for _,p := range pool.pairs { tests[string(sg.Encode(p.gk))] = true }
+		target := cheatServer{loc,func(q ExpQuery) bool { return !q.Base.Equal(sg.G) && !tests[string(sg.Encode(q.Base))] },lie}
+		_,_,e := pool.Exp(rng,[2]ExpServer{target,loc},sg.pow(sg.G,big.NewInt(5)),big.NewInt(1234567))
+		if (e==ErrServerCheat)!=malformed[name] { t.Errorf("%s on the real query: %v",name,e) }
+	}
+	// Without knowing, a single false answer is caught with probability 3/4 or more.
+	caught := 0
+	for i := 0; i<60; i++ {
+		k,pick := 0,rand.New(rand.NewSource(int64(i))).Intn(4)
+		one := cheatServer{loc,func(ExpQuery) bool { k++; return k-1==pick },lies["squared"]}
+		if run(one,loc)==ErrServerCheat { caught++ }
+	}
+	if caught<30 { t.Errorf("caught %d of 60 cheats",caught) }
+	// Avoiding the shared query, which has the base G, leaves 2/3.
+	caught = 0
+	for i := 0; i<90; i++ {
+		k,pick := 0,rand.New(rand.NewSource(int64(i))).Intn(3)
+		one := cheatServer{loc,func(q ExpQuery) bool {
+			if q.Base.Equal(sg.G) { return false }
+			k++
+			return k-1==pick
+		},lies["squared"]}
+		if run(one,loc)==ErrServerCheat { caught++ }
+	}
+	if caught<45 || caught>75 { t.Errorf("caught %d of 90 cheats avoiding the shared query",caught) }
+	for _,f := range []failServer{{-1},{0},{3},{5}} {
+		if e := run(f,loc); e==nil { t.Errorf("server answering %d results accepted",f.n) }
+	}
+}
+
+// Pairs taken by an exponentiation must survive a concurrent Refill.
+func TestExpPoolRefill(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	pool,e := sg.NewExpPool(rng,2*expPairsPerCall)
+	if e!=nil { t.Fatal(e) }
+	held,_ := pool.take(expPairsPerCall)
+	ks := make([]*big.Int,len(held))
+	for i,p := range held { ks[i] = p.k }
+	if e := pool.Refill(rng,expPairsPerCall); e!=nil { t.Fatal(e) }
+	for i,p := range held {
+		if p.k!=ks[i] { t.Fatalf("pair %d overwritten by Refill",i) }
+	}
+	loc := LocalServer{sg.Algebra}
+	done := make(chan error)
+	for w := 0; w<4; w++ {
+		go func(seed int64) {
+			rng := rand.New(rand.NewSource(seed))
+			for i := 0; i<3; i++ {
+				if e := pool.Refill(rng,expPairsPerCall); e!=nil { done <- e; return }
+				u,a := sg.pow(sg.G,big.NewInt(rng.Int63())),big.NewInt(rng.Int63())
+				r,_,e := pool.Exp(rng,[2]ExpServer{loc,loc},u,a)
+				if e==nil && !r.Equal(sg.pow(u,a)) { e = errors.New("wrong result") }
+				if e!=nil { done <- e; return }
+			}
+			done <- nil
+		}(int64(w))
+	}
+	for w := 0; w<4; w++ {
+		if e := <-done; e!=nil { t.Error(e) }
+	}
+}
@@ -0,0 +1,127 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package hypercomplex
+
+import "math/big"
+import "sort"
+import "fmt"
+import "errors"
+
+/*
+A named parameter set: a prime modulus, the dimension of the elements and the
+prime order of the subgroup that protocols work in. Both primes come with a
+Certificate, so a consumer can check them without trusting the source.
+*/
+type ParamSet struct{
+	Name string
+	Modulus Modulus
+	Dim int
+	Order *big.Int
+	ModulusCert,OrderCert *Certificate
+}
+
+/*
+Returns the exponent of the unit group of the multicomplex numbers of
+dimension dim, assuming P is an odd prime: P-1 if the ring splits into copies
+of F_P (dim=1 or P = 1 mod 4), P^2-1 if it splits into copies of F_{P^2}.
+*/
+func (m Modulus) unitExponent(dim int) *big.Int {
+	one := big.NewInt(1)
+	if dim<2 || m.Mod.Bit(1)==0 { return new(big.Int).Sub(m.Mod,one) }
+	e := new(big.Int).Mul(m.Mod,m.Mod)
+	return e.Sub(e,one)
+}
+
+// Checks both certificates and that Order divides the order of the unit group.
+func (ps *ParamSet) Verify() error {
+	if ps.ModulusCert==nil || ps.OrderCert==nil { return errors.New("Missing certificate") }
+	if ps.ModulusCert.N.Cmp(ps.Modulus.Mod)!=0 { return errors.New("Certificate does not match the modulus") }
+	if ps.OrderCert.N.Cmp(ps.Order)!=0 { return errors.New("Certificate does not match the order") }
+	if ps.Dim<1 || (ps.Dim&(ps.Dim-1))!=0 { return errors.New("Must be power of two") }
+	if e := ps.ModulusCert.Verify(); e!=nil { return e }
+	if e := ps.OrderCert.Verify(); e!=nil { return e }
+	if new(big.Int).Mod(ps.Modulus.unitExponent(ps.Dim),ps.Order).Sign()!=0 {
+		return errors.New("Order does not divide the order of the unit group")
+	}
+	return nil
+}
+
+var paramSets = make(map[string]*ParamSet)
+
+/*
+Registers a parameter set from the text form of its modulus certificate. The
+order must be one of the certified factors of P-1.
+*/
+func defineParams(name string, dim int, order string, cert string) {
+	ps := &ParamSet{Name:name,Dim:dim,ModulusCert:new(Certificate)}
+	if e := ps.ModulusCert.UnmarshalText([]byte(cert)); e!=nil { panic(e) }
+	ps.Modulus = Modulus{ps.ModulusCert.N}
+	ps.Order,_ = new(big.Int).SetString(order,16)
+	for _,s := range ps.ModulusCert.Steps {
+		if s.Q.Cmp(ps.Order)==0 { ps.OrderCert = s.Cert }
+	}
+	paramSets[name] = ps
+}
+
+// Looks up a named parameter set. The result is shared; do not modify it.
+func Params(name string) (*ParamSet,error) {
+	ps,ok := paramSets[name]
+	if !ok { return nil,fmt.Errorf("Unknown parameter set %q",name) }
+	return ps,nil
+}
+
+// Lists the names of all parameter sets.
+func ParamNames() []string {
+	names := make([]string,0,len(paramSets))
+	for n := range paramSets { names = append(names,n) }
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	// 256-bit P = 3 (mod 4), 250-bit order dividing P-1.
+	defineParams("hc256-4",4,"28200f63b0c36bae0894822fde89f01d337bd27df61f165efca25267e1bab0f",`
+3f7d8780f 2^1:3 3^1:2 5^3:2 1d^1:2 2b^1:2 472f^1:2
+1d4824656d 2^2:2 1d^1:2 101^1:2 405ed7^1:2
+66f8a35b4f 2^1:5 33b^1:2 871^1:2 1e355^1:2
+fc1623629 2^3:3 5^1:2 64d5a7c1^1:2
+6896cf29fcb4d75d5 2^2:2 1d^1:2 a3d^1:2 16e5^1:2 fc1623629^1:2
+981e3d9a2e0d92917b9876b1463802ed 2^2:2 b^3:2 13^1:2 133e7^1:2 c890011^1:2 6896cf29fcb4d75d5^1:2
+28200f63b0c36bae0894822fde89f01d337bd27df61f165efca25267e1bab0f 2^1:5 b^1:2 10d^1:2 3f7d8780f^1:2 1d4824656d^1:2 66f8a35b4f^1:2 981e3d9a2e0d92917b9876b1463802ed^1:2
+a5843f7b39261c2de364990575f8fe78745ec44797403c47d21d93ec832219df 2^1:3 3^1:3 b^1:2 28200f63b0c36bae0894822fde89f01d337bd27df61f165efca25267e1bab0f^1:2
+`)
+	// 384-bit P = 1 (mod 4), 378-bit order dividing P-1.
+	defineParams("hc384-8",8,"370482f01563d688363c539aae214edf0f334aafc4bdb3e8f9ad8ac6ff3c1cd1bef496954a60b0ef0b90b6497ad5d11",`
+392644d1f62351 2^4:3 13^2:2 25^1:2 503^1:2 37f3163^1:2
+81fbcb8cab90adba40255b6f6f 2^1:7 5^3:3 d^1:2 35^1:2 1fd^1:2 6f6b3f^1:2 392644d1f62351^1:2
+f91ae080e6d8ed895472a0aea94089cbbf 2^1:3 3^2:3 61^1:2 47eee9^1:2 81fbcb8cab90adba40255b6f6f^1:2
+c97cc6b7d 2^2:2 113dd^1:2 2ebeb^1:2
+e5d02cbf147cb 2^1:2 7^1:2 17^1:2 6371^1:2 1d65c895^1:2
+6689bf18bf20cc5b60faa572f 2^1:3 3^3:2 2b^1:2 c97cc6b7d^1:2 e5d02cbf147cb^1:2
+8159321477882b5861b1cd15e45ce8d702db85a6520912b7 2^1:5 503^1:2 909f3b^1:2 f2b65cd^1:2 3c2703b9^1:2 6689bf18bf20cc5b60faa572f^1:2
+370482f01563d688363c539aae214edf0f334aafc4bdb3e8f9ad8ac6ff3c1cd1bef496954a60b0ef0b90b6497ad5d11 2^4:3 17^1:2 17e87^1:2 3418ae9^1:2 f91ae080e6d8ed895472a0aea94089cbbf^1:2 8159321477882b5861b1cd15e45ce8d702db85a6520912b7^1:2
+974c68143ad28df69525e5e95edb98e569cd0d635d09aec0ae9d3da33de54f40cd209e1a8c89e6915fcdf54a11cbfeed 2^2:2 b^1:2 370482f01563d688363c539aae214edf0f334aafc4bdb3e8f9ad8ac6ff3c1cd1bef496954a60b0ef0b90b6497ad5d11^1:2
+`)
+}
@@ -0,0 +1,89 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math/big"
+import "io"
+import "errors"
+
+/*
+Pedersen commitments C = G^m * H^r, where H is derived from a label with
+Group.Derive, so nobody knows its logarithm to the base G. Openings are
+non-negative integers; like Sign, this needs no knowledge of the order of G.
+
+Binding rests on the hardness of finding a relation between G and H, and
+holds only modulo the order of G: m and m+ord(G) open the same commitment.
+The Pedersen of a Subgroup therefore accepts only messages below Q; that of
+a plain Group accepts any m >= 0, and a caller comparing openings must do so
+modulo the order. Hiding is statistical when H lies in the group generated
+by G, which holds for the Pedersen of a Subgroup (H is projected into the
+subgroup); otherwise the commitment hides m only computationally.
+*/
+type Pedersen struct{
+	Group
+	H MultiComp
+	randBits int
+	order *big.Int // messages must be below it, if not nil
+}
+
+func (g Group) Pedersen(label []byte) (*Pedersen,error) {
+	h,e := g.Derive(label,[]byte("pedersen"),0)
+	if e!=nil { return nil,e }
+	return &Pedersen{g,h,8*g.privLen()+slackBits,nil},nil
+}
+
+// A Pedersen commitment scheme with H projected into the subgroup.
+func (sg *Subgroup) Pedersen(label []byte) (*Pedersen,error) {
+	for i := uint64(0); i<64; i++ {
+		h,e := sg.Derive(label,[]byte("pedersen"),i)
+		if e!=nil { return nil,e }
+		h = sg.Exp(h,sg.cof.Bytes())
+		if !isOne(h) { return &Pedersen{sg.Group,h,sg.Q.BitLen()+slackBits,sg.Q},nil }
+	}
+	return nil,errors.New("No commitment base found")
+}
+
+// Commits to m >= 0, and m < Q for the Pedersen of a Subgroup. Returns the
+// commitment and the randomness to open it.
+func (p *Pedersen) Commit(rand io.Reader, m *big.Int) (MultiComp,*big.Int,error) {
+	if !p.validMessage(m) { return nil,nil,errors.New("Message out of range") }
+	buf := make([]byte,(p.randBits+7)/8)
+	if _,e := io.ReadFull(rand,buf); e!=nil { return nil,nil,e }
+	r := new(big.Int).SetBytes(buf)
+	return p.commit(m,r),r,nil
+}
+
+func (p *Pedersen) validMessage(m *big.Int) bool {
+	return m!=nil && m.Sign()>=0 && (p.order==nil || m.Cmp(p.order)<0)
+}
+
+func (p *Pedersen) commit(m,r *big.Int) MultiComp {
+	return p.Multiply(p.Exp(p.G,m.Bytes()),p.Exp(p.H,r.Bytes()))
+}
+
+// Checks that c opens to m with randomness r.
+func (p *Pedersen) Open(c MultiComp, m,r *big.Int) bool {
+	if !p.validMessage(m) || r==nil || r.Sign()<0 || len(c)!=len(p.G) { return false }
+	return p.commit(m,r).Equal(c)
+}
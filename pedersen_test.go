@@ -0,0 +1,60 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+
+func TestPedersen(t *testing.T) {
+	sg,rng := testSubgroup(t)
+	plain,e := Group{Algebra:sg.Algebra,G:sg.G}.Pedersen([]byte("test"))
+	if e!=nil { t.Fatal(e) }
+	sub,e := sg.Pedersen([]byte("test"))
+	if e!=nil { t.Fatal(e) }
+	if !sg.Contains(sub.H) || sg.Contains(plain.H) { t.Fatal("H in the wrong group") }
+	if other,_ := sg.Pedersen([]byte("other")); other.H.Equal(sub.H) { t.Fatal("labels give the same H") }
+	one := big.NewInt(1)
+	for _,p := range []*Pedersen{plain,sub} {
+		m1,m2 := big.NewInt(rng.Int63()),big.NewInt(rng.Int63())
+		c1,r1,e := p.Commit(rng,m1)
+		if e!=nil { t.Fatal(e) }
+		c2,r2,_ := p.Commit(rng,m2)
+		if !p.Open(c1,m1,r1) { t.Fatal("commitment does not open") }
+		if c3,_,_ := p.Commit(rng,m1); c3.Equal(c1) { t.Error("commitment is not randomized") }
+		if p.Open(c1,m2,r1) || p.Open(c1,new(big.Int).Add(m1,one),r1) || p.Open(c1,m1,r2) { t.Error("wrong opening accepted") }
+		if p.Open(c1,nil,r1) || p.Open(c1,m1,nil) || p.Open(c1,m1,new(big.Int).Neg(r1)) || p.Open(c1[:2],m1,r1) { t.Error("malformed opening accepted") }
+		// Commitments are additively homomorphic.
+		sum := p.Multiply(c1,c2)
+		if !p.Open(sum,new(big.Int).Add(m1,m2),new(big.Int).Add(r1,r2)) { t.Error("product does not open to the sum") }
+		if _,_,e := p.Commit(rng,big.NewInt(-1)); e==nil { t.Error("negative message accepted") }
+	}
+	// Binding holds modulo Q only, so the subgroup variant rejects m >= Q.
+	m := big.NewInt(42)
+	c,r,_ := sub.Commit(rng,m)
+	if sub.Open(c,new(big.Int).Add(m,sg.Q),r) { t.Error("opening to m+Q accepted") }
+	if _,_,e := sub.Commit(rng,sg.Q); e==nil { t.Error("message Q accepted") }
+	if c,r,e := sub.Commit(rng,new(big.Int).Sub(sg.Q,one)); e!=nil || !sub.Open(c,new(big.Int).Sub(sg.Q,one),r) { t.Error("message Q-1 rejected") }
+	large := new(big.Int).Lsh(sg.Q,2)
+	if c,r,e := plain.Commit(rng,large); e!=nil || !plain.Open(c,large,r) { t.Error("plain commitment rejects a large message") }
+}
@@ -0,0 +1,160 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math"
+import "math/cmplx"
+import "errors"
+
+var ErrNoConvergence = errors.New("Iteration did not converge")
+var ErrSingular = errors.New("Derivative is not invertible")
+
+/*
+Finds all roots of the complex polynomial sum(coeffs[k]*z^k) with the
+Durand-Kerner (Weierstrass) iteration. Iterates until no root moves by more
+than tol (relative to its magnitude), at most maxIter times. Returns the roots
+and the number of iterations used.
+*/
+func DurandKerner(coeffs []complex128, tol float64, maxIter int) ([]complex128,int,error) {
+	n := len(coeffs)-1
+	for n>=0 && coeffs[n]==0 { n-- }
+	if n<0 { return nil,0,errors.New("Zero polynomial") }
+	monic := make([]complex128,n+1)
+	for k := range monic { monic[k] = coeffs[k]/coeffs[n] }
+	eval := func(z complex128) complex128 {
+		v := complex(0,0)
+		for k := n; k>=0; k-- { v = v*z+monic[k] }
+		return v
+	}
+	z := make([]complex128,n)
+	for k := range z { z[k] = cmplx.Pow(0.4+0.9i,complex(float64(k),0)) }
+	for it := 1; it<=maxIter; it++ {
+		moved := 0.0
+		for i := range z {
+			d := complex(1,0)
+			for j := range z {
+				if j!=i { d *= z[i]-z[j] }
+			}
+			if d==0 { d = complex(tol,0) }
+			step := eval(z[i])/d
+			z[i] -= step
+			moved = math.Max(moved,cmplx.Abs(step)/(1+cmplx.Abs(z[i])))
+		}
+		if moved<=tol { return z,it,nil }
+	}
+	return z,maxIter,ErrNoConvergence
+}
+
+/*
+Finds the roots of the polynomial sum(coeffs[k]*z^k) with multicomplex
+coefficients (all of the same length >= 2). The polynomial splits into one
+complex polynomial per idempotent component (see MultiFloat.Components);
+each one is solved with DurandKerner, and every choice of one root per
+component gives a multicomplex root. A component polynomial of degree d_j
+thus leads to d_1*d_2*... roots in total.
+
+Leading coefficients that vanish in a component (relative to the largest
+coefficient of that component, within tol) lower its degree. If a component
+polynomial vanishes entirely, there are infinitely many roots and an error is
+returned.
+*/
+func PolyRoots(coeffs []MultiFloat, tol float64, maxIter int) ([]MultiFloat,error) {
+	if len(coeffs)==0 { return nil,errors.New("Zero polynomial") }
+	comps := make([][]complex128,len(coeffs))
+	for k,c := range coeffs {
+		if len(c)<2 || len(c)!=len(coeffs[0]) { return nil,errors.New("Coefficients must have equal length >= 2") }
+		comps[k] = c.Components()
+	}
+	n := len(comps[0])
+	roots := make([][]complex128,n)
+	for j := 0; j<n; j++ {
+		p := make([]complex128,len(coeffs))
+		top := 0.0
+		for k := range p {
+			p[k] = comps[k][j]
+			top = math.Max(top,cmplx.Abs(p[k]))
+		}
+		if top==0 { return nil,errors.New("Polynomial vanishes in a component") }
+		for k := range p {
+			if cmplx.Abs(p[k])<=tol*top { p[k] = 0 }
+		}
+		r,_,e := DurandKerner(p,tol,maxIter)
+		if e!=nil { return nil,e }
+		if len(r)==0 { return nil,nil } // a non-zero constant: no roots at all
+		roots[j] = r
+	}
+
+	// Mixed-radix enumeration of one root per component.
+	var res []MultiFloat
+	idx := make([]int,n)
+	c := make([]complex128,n)
+	for {
+		for j := range c { c[j] = roots[j][idx[j]] }
+		res = append(res,FromComponents(c))
+		j := 0
+		for ; j<n; j++ {
+			idx[j]++
+			if idx[j]<len(roots[j]) { break }
+			idx[j] = 0
+		}
+		if j==n { return res,nil }
+	}
+}
+
+// Convergence diagnostics of Newton.
+type NewtonInfo struct{
+	Iterations int
+	Converged bool
+	// Norm of f at each iterate, starting with x0.
+	Residuals []float64
+	// Norm of the last step.
+	Step float64
+}
+
+/*
+Newton's iteration x <- x - f(x)/df(x) for a multicomplex analytic function f
+with derivative df. Stops once the step is at most tol*(1+|x|) or the
+residual is at most tol, after at most maxIter steps. Fails with
+ErrSingular if df(x) is a zero divisor, and with ErrNoConvergence if maxIter
+is exhausted; the last iterate and the diagnostics are returned either way.
+*/
+func Newton(f,df func(MultiFloat) MultiFloat, x0 MultiFloat, tol float64, maxIter int) (MultiFloat,NewtonInfo,error) {
+	var info NewtonInfo
+	x := x0.Copy()
+	fx := f(x)
+	info.Residuals = append(info.Residuals,fx.Norm())
+	for info.Iterations<maxIter {
+		if fx.Norm()<=tol { info.Converged = true; return x,info,nil }
+		inv := df(x).Inverse()
+		if !inv.finite() { return x,info,ErrSingular }
+		step := fx.Multiply(inv)
+		x = x.Sub(step)
+		info.Iterations++
+		info.Step = step.Norm()
+		fx = f(x)
+		info.Residuals = append(info.Residuals,fx.Norm())
+		if info.Step<=tol*(1+x.Norm()) { info.Converged = true; return x,info,nil }
+	}
+	return x,info,ErrNoConvergence
+}
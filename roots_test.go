@@ -0,0 +1,106 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math"
+import "math/cmplx"
+import "sort"
+
+func near(a,b MultiFloat, tol float64) bool {
+	return len(a)==len(b) && a.Sub(b).Norm()<=tol*(1+b.Norm())
+}
+
+func TestComponents(t *testing.T) {
+	a := MultiFloat{1,2,-3,0.5,4,-1,2,7}
+	b := MultiFloat{-2,1,0.25,3,1,1,-5,2}
+	ca,cb,cab := a.Components(),b.Components(),a.Multiply(b).Components()
+	for k := range cab {
+		if cmplx.Abs(cab[k]-ca[k]*cb[k])>1e-12*cmplx.Abs(cab[k]) { t.Fatalf("component %d: %v != %v*%v",k,cab[k],ca[k],cb[k]) }
+	}
+	if !near(FromComponents(ca),a,1e-15) { t.Fatalf("FromComponents(Components(a)) = %v",FromComponents(ca)) }
+	if c := (MultiFloat{3}).Components(); c!=nil { t.Errorf("real number has components %v",c) }
+	if f := FromComponents(nil); f!=nil { t.Errorf("FromComponents(nil) = %v",f) }
+}
+
+func TestDurandKerner(t *testing.T) {
+	// (z-1)(z-2)(z-3) = z^3 - 6z^2 + 11z - 6
+	r,it,e := DurandKerner([]complex128{-6,11,-6,1},1e-14,500)
+	if e!=nil { t.Fatal(e) }
+	re := make([]float64,len(r))
+	for i,z := range r {
+		if math.Abs(imag(z))>1e-9 { t.Fatalf("root %v is not real",z) }
+		re[i] = real(z)
+	}
+	sort.Float64s(re)
+	for i,w := range []float64{1,2,3} {
+		if math.Abs(re[i]-w)>1e-9 { t.Fatalf("roots %v, want 1 2 3 (%d iterations)",re,it) }
+	}
+	// 2z^4 + 2: the four primitive 8th roots of unity.
+	r,_,e = DurandKerner([]complex128{2,0,0,0,2},1e-14,500)
+	if e!=nil || len(r)!=4 { t.Fatal(r,e) }
+	for _,z := range r {
+		if cmplx.Abs(z*z*z*z+1)>1e-9 { t.Fatalf("%v is no root of z^4+1",z) }
+	}
+	if _,_,e := DurandKerner([]complex128{0,0},1e-14,10); e==nil { t.Error("zero polynomial accepted") }
+	if _,_,e := DurandKerner([]complex128{1,0,1,5,7,1},1e-300,2); e!=ErrNoConvergence { t.Errorf("got %v, want ErrNoConvergence",e) }
+}
+
+func TestPolyRoots(t *testing.T) {
+	// z^2 - c for an invertible bicomplex c: two components, two roots each.
+	c := MultiFloat{1,2,-0.5,3}
+	r,e := PolyRoots([]MultiFloat{c.Scale(-1),{0,0,0,0},{1,0,0,0}},1e-14,500)
+	if e!=nil { t.Fatal(e) }
+	if len(r)!=4 { t.Fatalf("%d roots, want 4",len(r)) }
+	for i,z := range r {
+		if !near(z.Multiply(z),c,1e-12) { t.Fatalf("root %v squares to %v, want %v",z,z.Multiply(z),c) }
+		for _,w := range r[:i] {
+			if near(z,w,1e-9) { t.Fatalf("root %v found twice",z) }
+		}
+	}
+	if _,e := PolyRoots([]MultiFloat{{1}},1e-14,10); e==nil { t.Error("real coefficients accepted") }
+	if _,e := PolyRoots([]MultiFloat{{0,0},{0,0}},1e-14,10); e==nil { t.Error("zero polynomial accepted") }
+	if r,e := PolyRoots([]MultiFloat{{1,0}},1e-14,10); e!=nil || r!=nil { t.Errorf("constant has roots %v, %v",r,e) }
+}
+
+func TestNewton(t *testing.T) {
+	c := MultiFloat{2,-1,0.5,1,3,0,-1,2}
+	f := func(x MultiFloat) MultiFloat { return x.Multiply(x).Sub(c) }
+	df := func(x MultiFloat) MultiFloat { return x.Scale(2) }
+	x0 := MultiFloat{1,0,0,0,0,0,0,0}
+	x,info,e := Newton(f,df,x0,1e-13,100)
+	if e!=nil || !info.Converged { t.Fatal(e,info) }
+	if !near(x.Multiply(x),c,1e-12) { t.Fatalf("%v squares to %v",x,x.Multiply(x)) }
+	if len(info.Residuals)!=info.Iterations+1 { t.Fatalf("%d residuals for %d iterations",len(info.Residuals),info.Iterations) }
+	if last := info.Residuals[len(info.Residuals)-1]; last>1e-10 { t.Fatalf("final residual %g",last) }
+
+	_,info,e = Newton(f,df,x0,1e-300,2)
+	if e!=ErrNoConvergence || info.Converged || info.Iterations!=2 { t.Errorf("got %v, %+v",e,info) }
+
+	// 1+i1*i2 is a zero divisor of the bicomplex numbers.
+	zd := MultiFloat{1,0,0,1}
+	g := func(x MultiFloat) MultiFloat { return x.Multiply(x).Sub(MultiFloat{2,1,0,0}) }
+	_,_,e = Newton(g,func(MultiFloat) MultiFloat { return zd },MultiFloat{1,0,0,0},1e-13,10)
+	if e!=ErrSingular { t.Errorf("got %v, want ErrSingular",e) }
+}
@@ -0,0 +1,76 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "math/big"
+import "crypto/sha256"
+import "io"
+import "errors"
+
+/*
+Schnorr signatures in the style of Girault, Poupard and Stern (GPS): the
+response s = r + c*x is computed over the integers, without any reduction,
+so neither the signer nor the verifier needs to know the order of G. This
+makes them work in every Algebra.
+
+The private exponent x is the key pair's Private as a big-endian integer and
+the public key is G^x. The challenge c has challengeBits bits, and the
+nonce r has 8*len(Private) + challengeBits + slackBits bits, which hides c*x
+statistically.
+*/
+type Signature struct{
+	T MultiComp
+	S *big.Int
+}
+
+const challengeBits = 128
+const slackBits = 128
+
+func (g Group) nonceBits() int { return 8*g.privLen()+challengeBits+slackBits }
+
+func (g Group) signChallenge(pub,t MultiComp, msg []byte) *big.Int {
+	h := sha256.Sum256(frame([]byte("hypercomplex/gps/v1"),g.Encode(g.G),g.Encode(pub),g.Encode(t),msg))
+	return new(big.Int).SetBytes(h[:challengeBits/8])
+}
+
+func (g Group) Sign(rand io.Reader, kp *KeyPair, msg []byte) (*Signature,error) {
+	if len(kp.Private)>g.privLen() { return nil,errors.New("Private key too long") }
+	buf := make([]byte,g.nonceBits()/8)
+	if _,e := io.ReadFull(rand,buf); e!=nil { return nil,e }
+	r := new(big.Int).SetBytes(buf)
+	t := g.Exp(g.G,buf)
+	s := g.signChallenge(kp.Public,t,msg)
+	s.Mul(s,new(big.Int).SetBytes(kp.Private)).Add(s,r)
+	return &Signature{t,s},nil
+}
+
+// Checks G^s = T * pub^c, with s in range.
+func (g Group) Verify(pub MultiComp, msg []byte, sig *Signature) bool {
+	if sig==nil || sig.S==nil || sig.S.Sign()<0 || sig.S.BitLen()>g.nonceBits()+1 { return false }
+	if len(sig.T)!=len(g.G) || len(pub)!=len(g.G) { return false }
+	c := g.signChallenge(pub,sig.T,msg)
+	lhs := g.Exp(g.G,sig.S.Bytes())
+	rhs := g.Multiply(sig.T,g.Exp(pub,c.Bytes()))
+	return lhs.Equal(rhs)
+}
@@ -0,0 +1,59 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+import "math/rand"
+
+func TestSign(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	ps,e := Params("hc256-4")
+	if e!=nil { t.Fatal(e) }
+	for _,alg := range []Algebra{ps.Modulus,Jet{ps.Modulus.Mod}} {
+		g0,_ := alg.Deterministic(rng,4)
+		g := Group{Algebra:alg,G:g0}
+		kp,e := g.GenerateKey(rng)
+		if e!=nil { t.Fatal(e) }
+		other,_ := g.GenerateKey(rng)
+		msg := []byte("message")
+		sig,e := g.Sign(rng,kp,msg)
+		if e!=nil { t.Fatal(e) }
+		if !g.Verify(kp.Public,msg,sig) { t.Fatalf("%T: signature rejected",alg) }
+		if g.Verify(kp.Public,[]byte("massage"),sig) { t.Errorf("%T: signature accepted for another message",alg) }
+		if g.Verify(other.Public,msg,sig) { t.Errorf("%T: signature accepted for another key",alg) }
+		if g2,_ := g.Sign(rng,kp,msg); g2.T.Equal(sig.T) { t.Errorf("%T: nonce reused",alg) }
+		one := big.NewInt(1)
+		limit := new(big.Int).Lsh(one,uint(g.nonceBits()+1))
+		for _,s := range []*big.Int{new(big.Int).Add(sig.S,one),new(big.Int).Neg(sig.S),new(big.Int).Add(sig.S,limit),nil} {
+			if g.Verify(kp.Public,msg,&Signature{sig.T,s}) { t.Errorf("%T: signature accepted with S = %v",alg,s) }
+		}
+		for _,tt := range []MultiComp{g.Multiply(sig.T,g.G),sig.T[:2],nil} {
+			if g.Verify(kp.Public,msg,&Signature{tt,sig.S}) { t.Errorf("%T: signature accepted with T = %v",alg,tt) }
+		}
+		if g.Verify(kp.Public,msg,nil) || g.Verify(kp.Public[:2],msg,sig) { t.Errorf("%T: malformed input accepted",alg) }
+		long := &KeyPair{append(kp.Private,0),kp.Public}
+		if _,e := g.Sign(rng,long,msg); e==nil { t.Errorf("%T: overlong private key accepted",alg) }
+	}
+}
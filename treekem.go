@@ -0,0 +1,422 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "crypto/hkdf"
+import "crypto/sha256"
+import "encoding/binary"
+import "io"
+import "fmt"
+import "errors"
+
+/*
+TreeKEM group key agreement, after MLS (RFC 9420), with the hypercomplex
+Diffie-Hellman of Group.
+
+Members sit at the leaves of a left-balanced binary tree. Every non-blank
+node carries a key pair, and a member knows the private keys of the nodes on
+its direct path (the path from its leaf to the root). A commit replaces the
+committer's leaf key and its whole direct path with keys derived from a chain
+of path secrets, and seals each path secret to the copath subtree below the
+node, so every other member learns the secrets from the lowest common
+ancestor upwards. The sealed secrets carry the group context (the hash of
+the tree and the epoch) as associated data, so they cannot be replayed into
+another group. The last secret of the chain, the commit secret, drives
+the epoch key schedule.
+
+Nodes are numbered in-order: leaves have even numbers (leaf i is node 2i),
+parents odd ones. Adding a member blanks its direct path, removing one
+blanks its leaf and direct path; blank nodes are skipped by encrypting to
+their resolution instead.
+*/
+
+var ErrWrongEpoch = errors.New("Commit is for another epoch")
+var ErrRemoved = errors.New("Member has been removed")
+
+// Left-balanced tree arithmetic, with n leaves.
+
+func level(x int) int {
+	k := 0
+	for (x>>uint(k))&1==1 { k++ }
+	return k
+}
+func nodeWidth(n int) int {
+	if n==0 { return 0 }
+	return 2*(n-1)+1
+}
+func rootNode(n int) int {
+	w := nodeWidth(n)
+	k := 0
+	for 1<<uint(k+1) <= w { k++ }
+	return 1<<uint(k)-1
+}
+func leftChild(x int) int { return x^(1<<uint(level(x)-1)) }
+func rightChild(x, n int) int {
+	r := x^(3<<uint(level(x)-1))
+	for r>=nodeWidth(n) { r = leftChild(r) }
+	return r
+}
+func parentStep(x int) int {
+	k := uint(level(x))
+	b := (x>>(k+1))&1
+	return (x|1<<k)^(b<<(k+1))
+}
+func parentNode(x, n int) int {
+	p := parentStep(x)
+	for p>=nodeWidth(n) { p = parentStep(p) }
+	return p
+}
+func siblingNode(x, n int) int {
+	p := parentNode(x,n)
+	if x<p { return rightChild(p,n) }
+	return leftChild(p)
+}
+func directPath(x, n int) []int {
+	var d []int
+	for r := rootNode(n); x!=r; {
+		x = parentNode(x,n)
+		d = append(d,x)
+	}
+	return d
+}
+
+// A member's view of a TreeKEM group.
+type Member struct{
+	g Group
+	leaf int
+	n int
+	tree []MultiComp // public keys by node, nil if blank
+	priv map[int][]byte
+	epoch uint64
+	epochSecret,initSecret []byte
+}
+
+/*
+Replaces the committer's leaf key and direct path. Nodes[i] belongs to the i-th
+node of the direct path, bottom-up. Its path secret is sealed once for every
+node in the resolution of the copath child, in order.
+*/
+type UpdatePath struct{
+	LeafKey MultiComp
+	Nodes []PathNode
+}
+type PathNode struct{
+	Public MultiComp
+	Secrets []*Sealed
+}
+
+/*
+Moves the group from Epoch to Epoch+1. Removes are applied first, then Adds
+(each into the leftmost blank leaf, or a new one), then the Path.
+*/
+type Commit struct{
+	Epoch uint64
+	Sender int
+	Removes []int
+	Adds []MultiComp
+	Path *UpdatePath
+}
+
+/*
+Lets a new member join in the epoch of the commit that added it. Secrets
+holds the epoch secret and the path secret of the lowest common ancestor of
+the joiner and the committer, sealed to the joiner's leaf key with the
+context of the new epoch as associated data.
+*/
+type Welcome struct{
+	Epoch uint64
+	Leaf int
+	Sender int
+	Tree []MultiComp
+	Secrets *Sealed
+}
+
+func (m *Member) Epoch() uint64 { return m.epoch }
+func (m *Member) Leaf() int { return m.leaf }
+
+// Number of occupied leaves.
+func (m *Member) Size() int {
+	k := 0
+	for i := 0; i<m.n; i++ {
+		if m.tree[2*i]!=nil { k++ }
+	}
+	return k
+}
+
+// Derives an exported secret of the current epoch.
+func (m *Member) Export(label string, length int) ([]byte,error) {
+	return hkdf.Expand(sha256.New,m.epochSecret,"hypercomplex treekem exporter "+label,length)
+}
+
+func expand(secret []byte, label string) []byte {
+	b,e := hkdf.Expand(sha256.New,secret,"hypercomplex treekem "+label,32)
+	if e!=nil { panic(e) }
+	return b
+}
+
+func epochInfo(label string, epoch uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte("hypercomplex treekem "+label),epoch)
+}
+
+// Creates a group with a single member.
+func (g Group) NewTreeKEM(rand io.Reader) (*Member,error) {
+	kp,e := g.GenerateKey(rand)
+	if e!=nil { return nil,e }
+	m := &Member{g:g,n:1,tree:[]MultiComp{kp.Public},priv:map[int][]byte{0:kp.Private}}
+	m.initSecret = make([]byte,32)
+	m.epochSecret = make([]byte,32)
+	if _,e := io.ReadFull(rand,m.epochSecret); e!=nil { return nil,e }
+	return m,nil
+}
+
+func (m *Member) clone() *Member {
+	c := *m
+	c.tree = append([]MultiComp(nil),m.tree...)
+	c.priv = make(map[int][]byte,len(m.priv))
+	for k,v := range m.priv { c.priv[k] = v }
+	return &c
+}
+
+func (m *Member) blank(x int) {
+	m.tree[x] = nil
+	delete(m.priv,x)
+}
+
+func (m *Member) resolution(x int) []int {
+	if m.tree[x]!=nil { return []int{x} }
+	if level(x)==0 { return nil }
+	return append(m.resolution(leftChild(x)),m.resolution(rightChild(x,m.n))...)
+}
+
+func (m *Member) isAncestor(x, leafNode int) bool {
+	for _,y := range directPath(leafNode,m.n) {
+		if y==x { return true }
+	}
+	return false
+}
+
+// Applies removes and adds. Returns the leaves of the added members.
+func (m *Member) applyProposals(c *Commit) ([]int,error) {
+	for _,l := range c.Removes {
+		if l<0 || l>=m.n || m.tree[2*l]==nil || l==c.Sender { return nil,fmt.Errorf("Invalid removal of leaf %d",l) }
+		m.blank(2*l)
+		for _,x := range directPath(2*l,m.n) { m.blank(x) }
+	}
+	var added []int
+	for _,pub := range c.Adds {
+		if len(pub)!=len(m.g.G) { return nil,errors.New("Invalid leaf key") }
+		l := 0
+		for l<m.n && m.tree[2*l]!=nil { l++ }
+		if l==m.n {
+			m.n++
+			for len(m.tree)<nodeWidth(m.n) { m.tree = append(m.tree,nil) }
+		}
+		m.tree[2*l] = pub
+		for _,x := range directPath(2*l,m.n) { m.blank(x) }
+		added = append(added,l)
+	}
+	return added,nil
+}
+
+/*
+Hash of the public tree and the epoch, binding the key schedule to the
+group state.
+*/
+func (m *Member) context() []byte {
+	h := sha256.New()
+	binary.Write(h,binary.BigEndian,m.epoch)
+	for _,pub := range m.tree {
+		if pub==nil {
+			h.Write([]byte{0})
+		} else {
+			h.Write([]byte{1})
+			h.Write(m.g.Encode(pub))
+		}
+	}
+	return h.Sum(nil)
+}
+
+func (m *Member) advance(commitSecret []byte) error {
+	m.epoch++
+	joiner,e := hkdf.Extract(sha256.New,commitSecret,m.initSecret)
+	if e!=nil { return e }
+	m.epochSecret,e = hkdf.Expand(sha256.New,joiner,string(frame([]byte("hypercomplex treekem epoch"),m.context())),32)
+	if e!=nil { return e }
+	m.initSecret = expand(m.epochSecret,"init")
+	return nil
+}
+
+/*
+Creates a commit that removes the given leaves, adds members with the given
+leaf keys and updates the committer's path. The commit is applied to m at
+once; the others call Process. Each added member gets a Welcome, in the
+order of adds.
+*/
+func (m *Member) Commit(rand io.Reader, adds []MultiComp, removes []int) (*Commit,[]*Welcome,error) {
+	c := &Commit{Epoch:m.epoch,Sender:m.leaf,Adds:adds,Removes:removes}
+	s := m.clone()
+	added,e := s.applyProposals(c)
+	if e!=nil { return nil,nil,e }
+
+	secret := make([]byte,32)
+	if _,e := io.ReadFull(rand,secret); e!=nil { return nil,nil,e }
+	info,aad := epochInfo("path",m.epoch),m.context()
+	kp,e := s.g.DeriveKeyPair(expand(secret,"node"))
+	if e!=nil { return nil,nil,e }
+	self := 2*s.leaf
+	s.tree[self],s.priv[self] = kp.Public,kp.Private
+	c.Path = &UpdatePath{LeafKey:kp.Public}
+	dp := directPath(self,s.n)
+	pathSecrets := make(map[int][]byte)
+	child := self
+	for _,x := range dp {
+		secret = expand(secret,"path")
+		pathSecrets[x] = secret
+		kp,e := s.g.DeriveKeyPair(expand(secret,"node"))
+		if e!=nil { return nil,nil,e }
+		pn := PathNode{Public:kp.Public}
+		for _,r := range s.resolution(siblingNode(child,s.n)) {
+			sealed,e := s.g.Seal(rand,s.tree[r],info,aad,secret)
+			if e!=nil { return nil,nil,e }
+			pn.Secrets = append(pn.Secrets,sealed)
+		}
+		s.tree[x],s.priv[x] = kp.Public,kp.Private
+		c.Path.Nodes = append(c.Path.Nodes,pn)
+		child = x
+	}
+	if e := s.advance(expand(secret,"path")); e!=nil { return nil,nil,e }
+
+	var ws []*Welcome
+	for _,l := range added {
+		var lca int
+		for _,x := range dp {
+			if s.isAncestor(x,2*l) { lca = x; break }
+		}
+		w := &Welcome{Epoch:s.epoch,Leaf:l,Sender:s.leaf,Tree:append([]MultiComp(nil),s.tree...)}
+		w.Secrets,e = s.g.Seal(rand,s.tree[2*l],epochInfo("welcome",s.epoch),s.context(),append(append([]byte(nil),s.epochSecret...),pathSecrets[lca]...))
+		if e!=nil { return nil,nil,e }
+		ws = append(ws,w)
+	}
+	*m = *s
+	return c,ws,nil
+}
+
+// Adds one member.
+func (m *Member) Add(rand io.Reader, pub MultiComp) (*Commit,*Welcome,error) {
+	c,ws,e := m.Commit(rand,[]MultiComp{pub},nil)
+	if e!=nil { return nil,nil,e }
+	return c,ws[0],nil
+}
+
+// Removes the member at the given leaf.
+func (m *Member) Remove(rand io.Reader, leaf int) (*Commit,error) {
+	c,_,e := m.Commit(rand,nil,[]int{leaf})
+	return c,e
+}
+
+// Refreshes the member's own keys.
+func (m *Member) Update(rand io.Reader) (*Commit,error) {
+	c,_,e := m.Commit(rand,nil,nil)
+	return c,e
+}
+
+/*
+Derives node key pairs from the path secret at dp[i] upwards, checks them
+against the published keys and stores the private keys. Returns the commit
+secret.
+*/
+func (m *Member) deriveUp(dp []int, i int, secret []byte) ([]byte,error) {
+	for ; i<len(dp); i++ {
+		kp,e := m.g.DeriveKeyPair(expand(secret,"node"))
+		if e!=nil { return nil,e }
+		if m.tree[dp[i]]==nil || !kp.Public.Equal(m.tree[dp[i]]) { return nil,errors.New("Path key does not match its secret") }
+		m.priv[dp[i]] = kp.Private
+		secret = expand(secret,"path")
+	}
+	return secret,nil
+}
+
+// Applies a commit of another member.
+func (m *Member) Process(c *Commit) error {
+	if c.Epoch!=m.epoch { return ErrWrongEpoch }
+	if c.Sender==m.leaf { return errors.New("Own commit") }
+	if c.Sender<0 || c.Sender>=m.n || m.tree[2*c.Sender]==nil { return errors.New("Unknown sender") }
+	s := m.clone()
+	if _,e := s.applyProposals(c); e!=nil { return e }
+	if s.tree[2*s.leaf]==nil { return ErrRemoved }
+	sender := 2*c.Sender
+	dp := directPath(sender,s.n)
+	if c.Path==nil || len(c.Path.Nodes)!=len(dp) || len(c.Path.LeafKey)!=len(s.g.G) { return errors.New("Invalid update path") }
+
+	// Find the lowest common ancestor and a resolution node whose key we hold.
+	i := 0
+	for !s.isAncestor(dp[i],2*s.leaf) { i++ }
+	child := sender
+	if i>0 { child = dp[i-1] }
+	res := s.resolution(siblingNode(child,s.n))
+	if len(res)!=len(c.Path.Nodes[i].Secrets) { return errors.New("Invalid update path") }
+	var secret []byte
+	for j,r := range res {
+		priv,ok := s.priv[r]
+		if !ok { continue }
+		var e error
+		secret,e = s.g.Open(&KeyPair{Private:priv,Public:s.tree[r]},c.Path.Nodes[i].Secrets[j],epochInfo("path",s.epoch),m.context())
+		if e!=nil { return e }
+		break
+	}
+	if secret==nil { return errors.New("No decryptable path secret") }
+
+	s.tree[sender] = c.Path.LeafKey
+	for k,x := range dp {
+		if len(c.Path.Nodes[k].Public)!=len(s.g.G) { return errors.New("Invalid update path") }
+		s.tree[x] = c.Path.Nodes[k].Public
+		delete(s.priv,x)
+	}
+	commitSecret,e := s.deriveUp(dp,i,secret)
+	if e!=nil { return e }
+	if e := s.advance(commitSecret); e!=nil { return e }
+	*m = *s
+	return nil
+}
+
+// Joins a group with the leaf key pair that was added.
+func (g Group) JoinTreeKEM(kp *KeyPair, w *Welcome) (*Member,error) {
+	if w.Leaf<0 || 2*w.Leaf>=len(w.Tree) || w.Tree[2*w.Leaf]==nil || !w.Tree[2*w.Leaf].Equal(kp.Public) {
+		return nil,errors.New("Welcome is not for this key")
+	}
+	if len(w.Tree)%2!=1 { return nil,errors.New("Invalid tree") }
+	m := &Member{g:g,leaf:w.Leaf,n:(len(w.Tree)+1)/2,tree:append([]MultiComp(nil),w.Tree...),epoch:w.Epoch}
+	m.priv = map[int][]byte{2*w.Leaf:kp.Private}
+	pt,e := g.Open(kp,w.Secrets,epochInfo("welcome",w.Epoch),m.context())
+	if e!=nil { return nil,e }
+	if len(pt)!=64 { return nil,errors.New("Invalid welcome secrets") }
+	m.epochSecret = pt[:32]
+	m.initSecret = expand(m.epochSecret,"init")
+	dp := directPath(2*w.Sender,m.n)
+	i := 0
+	for i<len(dp) && !m.isAncestor(dp[i],2*m.leaf) { i++ }
+	if i==len(dp) { return nil,errors.New("Invalid welcome") }
+	if _,e := m.deriveUp(dp,i,pt[32:]); e!=nil { return nil,e }
+	return m,nil
+}
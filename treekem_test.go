@@ -0,0 +1,186 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+import "math/rand"
+import "bytes"
+
+func TestTreeMath(t *testing.T) {
+	if rootNode(1)!=0 || rootNode(2)!=1 || rootNode(3)!=3 || rootNode(5)!=7 { t.Fatal("rootNode") }
+	if parentNode(8,5)!=7 || rightChild(7,5)!=8 || siblingNode(8,5)!=3 { t.Fatal("truncated right edge") }
+	for n := 1; n<70; n++ {
+		for l := 0; l<n; l++ {
+			dp := directPath(2*l,n)
+			if n>1 && dp[len(dp)-1]!=rootNode(n) { t.Fatalf("n=%d: direct path of leaf %d ends at %d",n,l,dp[len(dp)-1]) }
+			x := 2*l
+			for _,p := range dp {
+				if leftChild(p)!=x && rightChild(p,n)!=x { t.Fatalf("n=%d: %d is no child of %d",n,x,p) }
+				x = p
+			}
+		}
+	}
+}
+
+// An in-memory group: every commit is delivered to every other member.
+type kemSim struct{
+	t *testing.T
+	g Group
+	rng *rand.Rand
+	members []*Member
+}
+
+func (s *kemSim) key() *KeyPair {
+	kp,e := s.g.GenerateKey(s.rng)
+	if e!=nil { s.t.Fatal(e) }
+	return kp
+}
+
+func (s *kemSim) deliver(from *Member, c *Commit) {
+	for _,m := range s.members {
+		if m==from { continue }
+		if e := m.Process(c); e!=nil { s.t.Fatalf("leaf %d: %v",m.Leaf(),e) }
+	}
+}
+
+func (s *kemSim) join(kp *KeyPair, w *Welcome) {
+	m,e := s.g.JoinTreeKEM(kp,w)
+	if e!=nil { s.t.Fatal(e) }
+	s.members = append(s.members,m)
+}
+
+// All members agree on epoch, size and exported secret.
+func (s *kemSim) check() {
+	s.t.Helper()
+	x,_ := s.members[0].Export("test",32)
+	for _,m := range s.members {
+		y,_ := m.Export("test",32)
+		if !bytes.Equal(x,y) || m.Epoch()!=s.members[0].Epoch() || m.Size()!=len(s.members) {
+			s.t.Fatalf("leaf %d disagrees in epoch %d",m.Leaf(),m.Epoch())
+		}
+	}
+}
+
+func (s *kemSim) pick() *Member { return s.members[s.rng.Intn(len(s.members))] }
+
+func TestTreeKEM(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	g := Group{Algebra:Modulus{big.NewInt(1000000007)}}
+	g.G,_ = g.Deterministic(rng,2)
+	s := &kemSim{t:t,g:g,rng:rng}
+	creator,e := g.NewTreeKEM(rng)
+	if e!=nil { t.Fatal(e) }
+	s.members = []*Member{creator}
+	size := 256
+	if testing.Short() { size = 32 }
+	for len(s.members)<size {
+		kp := s.key()
+		adder := s.pick()
+		c,w,e := adder.Add(rng,kp.Public)
+		if e!=nil { t.Fatal(e) }
+		s.deliver(adder,c)
+		s.join(kp,w)
+		if len(s.members)%16==0 { s.check() }
+	}
+	s.check()
+	for r := 0; r<60; r++ {
+		who := s.pick()
+		switch r%3 {
+		case 0:
+			c,e := who.Update(rng)
+			if e!=nil { t.Fatal(e) }
+			s.deliver(who,c)
+		case 1:
+			victim := who
+			for victim==who { victim = s.pick() }
+			c,e := who.Remove(rng,victim.Leaf())
+			if e!=nil { t.Fatal(e) }
+			if e := victim.Process(c); e!=ErrRemoved { t.Fatalf("removed member got %v",e) }
+			var rest []*Member
+			for _,m := range s.members {
+				if m!=victim { rest = append(rest,m) }
+			}
+			s.members = rest
+			s.deliver(who,c)
+		case 2:
+			kp1,kp2 := s.key(),s.key()
+			var remove []int
+			if victim := s.pick(); victim!=who { remove = append(remove,victim.Leaf()) }
+			c,ws,e := who.Commit(rng,[]MultiComp{kp1.Public,kp2.Public},remove)
+			if e!=nil { t.Fatal(e) }
+			var rest []*Member
+			for _,m := range s.members {
+				if len(remove)>0 && m.Leaf()==remove[0] { continue }
+				rest = append(rest,m)
+			}
+			s.members = rest
+			s.deliver(who,c)
+			s.join(kp1,ws[0])
+			s.join(kp2,ws[1])
+		}
+		s.check()
+	}
+}
+
+func TestTreeKEMRejects(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	g := Group{Algebra:Modulus{big.NewInt(1000000007)}}
+	g.G,_ = g.Deterministic(rng,2)
+	s := &kemSim{t:t,g:g,rng:rng}
+	creator,_ := g.NewTreeKEM(rng)
+	s.members = []*Member{creator}
+	for i := 0; i<4; i++ {
+		kp := s.key()
+		c,w,e := creator.Add(rng,kp.Public)
+		if e!=nil { t.Fatal(e) }
+		s.deliver(creator,c)
+		s.join(kp,w)
+	}
+	c,_ := s.members[1].Update(rng)
+	s.deliver(s.members[1],c)
+	if e := s.members[2].Process(c); e!=ErrWrongEpoch { t.Errorf("replayed commit: got %v, want ErrWrongEpoch",e) }
+	c,_ = s.members[0].Update(rng)
+	c.Path.Nodes[len(c.Path.Nodes)-1].Public = MultiComp{big.NewInt(5),big.NewInt(7)}
+	if e := s.members[3].Process(c); e==nil { t.Error("tampered path accepted") }
+}
+
+// A member with the same leaf key in two groups must not accept commits of one group in the other.
+func TestTreeKEMCrossGroup(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	g := Group{Algebra:Modulus{big.NewInt(1000000007)}}
+	g.G,_ = g.Deterministic(rng,2)
+	kp,_ := g.GenerateKey(rng)
+	var creators,joined [2]*Member
+	for i := range creators {
+		creators[i],_ = g.NewTreeKEM(rng)
+		_,w,e := creators[i].Add(rng,kp.Public)
+		if e!=nil { t.Fatal(e) }
+		if joined[i],e = g.JoinTreeKEM(kp,w); e!=nil { t.Fatal(e) }
+	}
+	c,e := creators[0].Update(rng)
+	if e!=nil { t.Fatal(e) }
+	if e := joined[1].Process(c); e==nil { t.Error("commit of another group accepted") }
+	if e := joined[0].Process(c); e!=nil { t.Fatal(e) }
+}
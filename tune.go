@@ -0,0 +1,334 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package hypercomplex
+
+import "math/big"
+import "bytes"
+import "fmt"
+import "time"
+import "errors"
+import "crypto/rand"
+
+// A multiplication strategy.
+type Strategy int
+
+const (
+	// The recursion of Modulus.Multiply (four half-size products).
+	Schoolbook Strategy = iota
+	// Gauss' trick: three half-size products per level.
+	ThreeMult
+	// Pointwise products after the idempotent decomposition.
+	Diagonal
+	// One big.Int product after Kronecker substitution.
+	Kronecker
+	// The fixed-width limb kernels (see Fixed).
+	Unrolled
+	numStrategies
+)
+
+var strategyNames = [...]string{"schoolbook","threemult","diagonal","kronecker","unrolled"}
+
+func (s Strategy) String() string {
+	if s<0 || s>=numStrategies { return fmt.Sprintf("Strategy(%d)",int(s)) }
+	return strategyNames[s]
+}
+func (s Strategy) MarshalText() ([]byte,error) {
+	if s<0 || s>=numStrategies { return nil,errors.New("Unknown strategy") }
+	return []byte(strategyNames[s]),nil
+}
+func (s *Strategy) UnmarshalText(b []byte) error {
+	for i,n := range strategyNames {
+		if n==string(b) { *s = Strategy(i); return nil }
+	}
+	return fmt.Errorf("Unknown strategy %q",b)
+}
+
+/*
+A tuning profile: the strategies chosen for each dimension of a modulus,
+for Multiply/Square and for Exp. It can be stored (eg. with encoding/json)
+and later handed to LoadProfile to skip the benchmarks.
+*/
+type Profile struct{
+	Modulus string `json:"modulus"` // hexadecimal
+	Choices map[int]Strategy `json:"choices"`
+	ExpChoices map[int]Strategy `json:"exp_choices"`
+}
+
+/*
+A Modulus that dispatches Multiply, Square and Exp to the fastest strategy
+for the dimension of its operands. Exp is tuned on its own, as strategies
+with a costly setup (Diagonal, Unrolled) pay it only once per exponentiation.
+Dimensions that have not been tuned use Schoolbook.
+*/
+type Tuned struct{
+	Modulus
+	fixed *Fixed
+	diag *splitting
+	choices,expChoices map[int]Strategy
+	timings,expTimings map[int][]time.Duration
+}
+
+func (m Modulus) tuned() *Tuned {
+	t := &Tuned{Modulus:m}
+	t.choices,t.expChoices = make(map[int]Strategy),make(map[int]Strategy)
+	t.timings,t.expTimings = make(map[int][]time.Duration),make(map[int][]time.Duration)
+	t.fixed,_ = m.Fixed()
+	t.diag,_ = m.splitting()
+	return t
+}
+
+func (t *Tuned) available(s Strategy) bool {
+	switch s {
+	case Schoolbook,ThreeMult,Kronecker: return true
+	case Diagonal: return t.diag!=nil
+	case Unrolled: return t.fixed!=nil
+	}
+	return false
+}
+
+/*
+Benchmarks every available strategy for each of the given dimensions (powers
+of two) and picks the fastest one. Each strategy is checked against
+Schoolbook before it is timed.
+*/
+func (m Modulus) Tune(dims ...int) (*Tuned,error) {
+	t := m.tuned()
+	for _,n := range dims {
+		if n<=0 || (n&(n-1))!=0 { return nil,errors.New("Must be power of two") }
+		a,e := m.Deterministic(rand.Reader,n)
+		if e!=nil { return nil,e }
+		b,e := m.Deterministic(rand.Reader,n)
+		if e!=nil { return nil,e }
+		want := m.Multiply(a,b)
+		exp := make([]byte,8)
+		if _,e := rand.Read(exp); e!=nil { return nil,e }
+		wantExp := m.Exp(a,exp)
+		times := make([]time.Duration,numStrategies)
+		expTimes := make([]time.Duration,numStrategies)
+		best,bestExp := Schoolbook,Schoolbook
+		for s := Schoolbook; s<numStrategies; s++ {
+			if !t.available(s) { continue }
+			if !t.mul(s,a,b).Equal(want) || !t.exp(s,a,exp).Equal(wantExp) {
+				return nil,fmt.Errorf("Strategy %v is broken for dimension %d",s,n)
+			}
+			times[s] = measure(func(){ t.mul(s,a,b) })
+			expTimes[s] = measure(func(){ t.exp(s,a,exp) })
+			if times[s]<times[best] { best = s }
+			if expTimes[s]<expTimes[bestExp] { bestExp = s }
+		}
+		t.choices[n],t.expChoices[n] = best,bestExp
+		t.timings[n],t.expTimings[n] = times,expTimes
+	}
+	return t,nil
+}
+
+// Average duration of f, sampled for at least a millisecond.
+func measure(f func()) time.Duration {
+	f()
+	start := time.Now()
+	k := 0
+	for time.Since(start)<time.Millisecond { f(); k++ }
+	return time.Since(start)/time.Duration(k)
+}
+
+// Restores a Tuned from a profile, without benchmarking.
+func (m Modulus) LoadProfile(p *Profile) (*Tuned,error) {
+	if p.Modulus!=m.Mod.Text(16) { return nil,errors.New("Profile belongs to a different modulus") }
+	t := m.tuned()
+	for i,c := range []map[int]Strategy{p.Choices,p.ExpChoices} {
+		for n,s := range c {
+			if !t.available(s) { return nil,fmt.Errorf("Strategy %v is not available",s) }
+			if i==0 { t.choices[n] = s } else { t.expChoices[n] = s }
+		}
+	}
+	return t,nil
+}
+
+func (t *Tuned) Profile() *Profile {
+	p := &Profile{Modulus:t.Mod.Text(16),Choices:make(map[int]Strategy),ExpChoices:make(map[int]Strategy)}
+	for n,s := range t.choices { p.Choices[n] = s }
+	for n,s := range t.expChoices { p.ExpChoices[n] = s }
+	return p
+}
+
+// Returns the strategy used by Multiply and Square for the given dimension.
+func (t *Tuned) Choice(n int) Strategy { return t.choices[n] }
+
+// Returns the strategy used by Exp for the given dimension.
+func (t *Tuned) ExpChoice(n int) Strategy { return t.expChoices[n] }
+
+// Describes the choices (and the measurements, if any), one line per dimension and operation.
+func (t *Tuned) Report() string {
+	dims := make([]int,0,len(t.choices))
+	for n := range t.choices { dims = append(dims,n) }
+	for n := range t.expChoices {
+		if _,ok := t.choices[n]; !ok { dims = append(dims,n) }
+	}
+	for i := range dims {
+		for j := i; j>0 && dims[j]<dims[j-1]; j-- { dims[j],dims[j-1] = dims[j-1],dims[j] }
+	}
+	sb := new(bytes.Buffer)
+	line := func(op string, n int, s Strategy, times []time.Duration) {
+		fmt.Fprintf(sb,"dim %d %s: %v",n,op,s)
+		if times==nil { sb.WriteString(" (profile)") }
+		for s,d := range times {
+			if d>0 { fmt.Fprintf(sb," %v=%v",Strategy(s),d) }
+		}
+		sb.WriteByte('\n')
+	}
+	for _,n := range dims {
+		line("mul",n,t.choices[n],t.timings[n])
+		line("exp",n,t.expChoices[n],t.expTimings[n])
+	}
+	return sb.String()
+}
+
+func (t *Tuned) Multiply(a,b MultiComp) MultiComp { return t.mul(t.Choice(len(a)),a,b) }
+func (t *Tuned) Square(a MultiComp) MultiComp { return t.square(t.Choice(len(a)),a) }
+func (t *Tuned) Exp(g MultiComp, exp []byte) MultiComp { return t.exp(t.ExpChoice(len(g)),g,exp) }
+
+func (t *Tuned) exp(s Strategy, g MultiComp, exp []byte) MultiComp {
+	switch s {
+	case Diagonal: return t.diag.join(t.diag.exp(t.diag.split(g),exp))
+	case Unrolled: return t.fixed.Exp(g,exp)
+	}
+	v := zeroes(len(g))
+	v[0].SetUint64(1)
+	for _,k := range exp {
+		for j := 0; j<8; j++ {
+			v = t.square(s,v)
+			if (k&0x80)==0x80 {
+				v = t.mul(s,v,g)
+			}
+			k <<= 1
+		}
+	}
+	return v
+}
+
+func (t *Tuned) mul(s Strategy, a,b MultiComp) MultiComp {
+	switch s {
+	case ThreeMult: return t.mul3(a,b)
+	case Diagonal: return t.diag.join(t.diag.mul(t.diag.split(a),t.diag.split(b)))
+	case Kronecker: return t.kronecker(a,b)
+	case Unrolled: return t.fixed.Multiply(a,b)
+	}
+	return t.Modulus.Multiply(a,b)
+}
+
+func (t *Tuned) square(s Strategy, a MultiComp) MultiComp {
+	L := len(a)/2
+	switch s {
+	case Schoolbook:
+		if L==0 { return t.Modulus.Multiply(a,a) }
+		ar,ai := a[:L],a[L:]
+		ri := t.Modulus.Multiply(ar,ai)
+		return append(t.Sub(t.square(s,ar),t.square(s,ai)),t.Add(ri,ri)...)
+	case ThreeMult:
+		if L==0 { return t.Modulus.Multiply(a,a) }
+		/*
+		(ar + ai*i)^2 = (ar+ai)*(ar-ai) + 2*ar*ai*i
+		*/
+		ar,ai := a[:L],a[L:]
+		ri := t.mul3(ar,ai)
+		return append(t.mul3(t.Add(ar,ai),t.Sub(ar,ai)),t.Add(ri,ri)...)
+	case Diagonal:
+		c := t.diag.split(a)
+		return t.diag.join(t.diag.mul(c,c))
+	}
+	return t.mul(s,a,a)
+}
+
+func (t *Tuned) mul3(a,b MultiComp) MultiComp {
+	L := len(a)/2
+	if L==0 { return t.Modulus.Multiply(a,b) }
+	ar,ai := a[:L],a[L:]
+	br,bi := b[:L],b[L:]
+	/*
+	cr = ar*br - ai*bi
+	ci = (ar+ai)*(br+bi) - ar*br - ai*bi
+	*/
+	rr := t.mul3(ar,br)
+	ii := t.mul3(ai,bi)
+	x := t.mul3(t.Add(ar,ai),t.Add(br,bi))
+	return append(t.Sub(rr,ii),t.Sub(x,t.Add(rr,ii))...)
+}
+
+/*
+Kronecker substitution. With k units, index bit j of a coefficient is the
+exponent of unit j, and unit j becomes X^(3^j). The product then has digits
+0..2 in base 3, and X = 2^w packs it into a single integer. Digit 2 folds
+back to 0 with a sign flip, as every unit squares to -1.
+*/
+func (t *Tuned) kronecker(a,b MultiComp) MultiComp {
+	n := len(a)
+	k := 0
+	for 1<<uint(k)<n { k++ }
+	// 2^k products of size < P^2 per coefficient.
+	wb := (2*t.Mod.BitLen()+k+8)/8
+	pos := make([]int,n) // base-3 position of every coefficient
+	for i := range pos {
+		for j,p3 := 0,1; j<k; j,p3 = j+1,p3*3 {
+			if i&(1<<uint(j))!=0 { pos[i] += p3 }
+		}
+	}
+	slots := 1
+	for j := 0; j<k; j++ { slots *= 3 }
+	pack := func(x MultiComp) *big.Int {
+		buf := make([]byte,slots*wb)
+		for i,c := range x {
+			c = new(big.Int).Mod(c,t.Mod)
+			o := len(buf)-(pos[i]+1)*wb
+			c.FillBytes(buf[o:o+wb])
+		}
+		return new(big.Int).SetBytes(buf)
+	}
+	A := pack(a)
+	var C *big.Int
+	if &a[0]==&b[0] {
+		C = new(big.Int).Mul(A,A)
+	} else {
+		C = new(big.Int).Mul(A,pack(b))
+	}
+	buf := make([]byte,slots*wb)
+	C.FillBytes(buf)
+	c := zeroes(n)
+	v := new(big.Int)
+	for d := 0; d<slots; d++ {
+		o := len(buf)-(d+1)*wb
+		v.SetBytes(buf[o:o+wb])
+		if v.Sign()==0 { continue }
+		i,neg := 0,false
+		for j,r := 0,d; j<k; j,r = j+1,r/3 {
+			switch r%3 {
+			case 1: i |= 1<<uint(j)
+			case 2: neg = !neg
+			}
+		}
+		if neg { c[i].Sub(c[i],v) } else { c[i].Add(c[i],v) }
+	}
+	for _,x := range c { x.Mod(x,t.Mod) }
+	return c
+}
@@ -0,0 +1,94 @@
+/*
+MIT License
+
+Copyright (c) 2017 Simon Schmidt
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package hypercomplex
+
+import "testing"
+import "math/big"
+import "encoding/json"
+
+func hexModulus(s string) Modulus {
+	p,_ := new(big.Int).SetString(s,16)
+	return Modulus{p}
+}
+
+// P = 1 (mod 4) prime, P = 3 (mod 4) prime, and composites.
+var tuneModuli = []Modulus{
+	hexModulus("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed"),
+	hexModulus("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+	{big.NewInt(3*5*7*11*13*17*19)},
+	{big.NewInt(1000003*1000033)},
+}
+
+func TestStrategies(t *testing.T) {
+	for _,m := range tuneModuli {
+		tu := m.tuned()
+		for s := Schoolbook; s<numStrategies; s++ {
+			if !tu.available(s) { continue }
+			for _,n := range []int{1,2,4,8,16} {
+				a,b := randMC(m,n),randMC(m,n)
+				if got,want := tu.mul(s,a,b),m.Multiply(a,b); !got.Equal(want) {
+					t.Fatalf("P=%x, %v, dim %d: mul gives %v, want %v",m.Mod,s,n,got,want)
+				}
+				if got,want := tu.square(s,a),m.Multiply(a,a); !got.Equal(want) {
+					t.Fatalf("P=%x, %v, dim %d: square gives %v, want %v",m.Mod,s,n,got,want)
+				}
+				exp := []byte{0xa5,0x3c}
+				if got,want := tu.exp(s,a,exp),m.Exp(a,exp); !got.Equal(want) {
+					t.Fatalf("P=%x, %v, dim %d: exp gives %v, want %v",m.Mod,s,n,got,want)
+				}
+			}
+		}
+	}
+}
+
+func TestStrategyText(t *testing.T) {
+	for s := Schoolbook; s<numStrategies; s++ {
+		b,e := s.MarshalText()
+		if e!=nil { t.Fatal(e) }
+		var r Strategy
+		if e := r.UnmarshalText(b); e!=nil || r!=s { t.Fatalf("%v round-trips to %v, %v",s,r,e) }
+	}
+	var r Strategy
+	if r.UnmarshalText([]byte("fastest"))==nil { t.Error("unknown strategy accepted") }
+}
+
+func TestProfileJSON(t *testing.T) {
+	m := tuneModuli[0]
+	tu,e := m.Tune(1,2,4)
+	if e!=nil { t.Fatal(e) }
+	b,e := json.Marshal(tu.Profile())
+	if e!=nil { t.Fatal(e) }
+	var p Profile
+	if e := json.Unmarshal(b,&p); e!=nil { t.Fatal(e) }
+	lo,e := m.LoadProfile(&p)
+	if e!=nil { t.Fatal(e) }
+	for _,n := range []int{1,2,4} {
+		if lo.Choice(n)!=tu.Choice(n) || lo.ExpChoice(n)!=tu.ExpChoice(n) {
+			t.Fatalf("dim %d: loaded %v/%v, tuned %v/%v",n,lo.Choice(n),lo.ExpChoice(n),tu.Choice(n),tu.ExpChoice(n))
+		}
+		a,c := randMC(m,n),randMC(m,n)
+		if !lo.Multiply(a,c).Equal(m.Multiply(a,c)) { t.Fatalf("dim %d: loaded profile multiplies wrong",n) }
+	}
+	if _,e := tuneModuli[1].LoadProfile(&p); e==nil { t.Error("profile loaded for another modulus") }
+}